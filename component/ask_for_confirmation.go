@@ -4,29 +4,90 @@
 package component
 
 import (
-	"fmt"
+	"io"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
-var (
-	okResponsesMap = map[string]struct{}{
-		"y": {},
-		"Y": {},
-	}
+const (
+	// YesFlagName is the name of the flag skipping confirmations.
+	YesFlagName = "yes"
+	// YesFlagShorthand is the shorthand of the flag skipping confirmations.
+	YesFlagShorthand = "y"
 )
 
-// AskForConfirmation is used to prompt the user to confirm or deny a choice
+// WithAssumeYes answers confirmations with yes without asking when
+// assumeYes is true.
+func WithAssumeYes(assumeYes bool) PromptOpt {
+	return func(options *PromptOptions) error {
+		options.AssumeYes = options.AssumeYes || assumeYes
+		return nil
+	}
+}
+
+// WithYesFlag answers confirmations with yes without asking when the flag
+// added by AddYesFlag is set on cmd.
+func WithYesFlag(cmd *cobra.Command) PromptOpt {
+	return func(options *PromptOptions) error {
+		if yes, err := cmd.Flags().GetBool(YesFlagName); err == nil {
+			options.AssumeYes = options.AssumeYes || yes
+		}
+		return nil
+	}
+}
+
+// withDenyOnInvalidAnswer denies a confirmation, rather than asking again,
+// when the answer is not a yes or no answer or cannot be read.
+func withDenyOnInvalidAnswer() PromptOpt {
+	return func(options *PromptOptions) error {
+		options.denyOnInvalidAnswer = true
+		return nil
+	}
+}
+
+// AddYesFlag adds the --yes/-y flag to cmd, to skip the confirmations asked
+// with the WithYesFlag option.
+func AddYesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP(YesFlagName, YesFlagShorthand, false, "Skip the confirmation and assume yes")
+}
+
+// AskForConfirmation is used to prompt the user to confirm or deny a choice.
+// In non-interactive mode, the answer is taken from the answers keyed by the
+// message.
 func AskForConfirmation(message string) error {
-	var response string
-	msg := message + " [y/N]: "
-	fmt.Print(msg)
-	_, err := fmt.Scanln(&response)
-	if err != nil {
-		return errors.New("aborted")
+	return AskForConfirmationWithOptions(&ConfirmConfig{Message: message})
+}
+
+// AskForConfirmationWithOptions asks the confirmation p, one line at a time,
+// and returns an error if the choice is denied. Answers are "y", "yes", "n"
+// or "no" in any case, and any other answer denies the choice.
+func AskForConfirmationWithOptions(p *ConfirmConfig, opts ...PromptOpt) error {
+	var confirmed bool
+	if err := Confirm(p, &confirmed, append(opts, withDenyOnInvalidAnswer())...); err != nil {
+		return err
 	}
-	if _, exit := okResponsesMap[response]; !exit {
+	if !confirmed {
 		return errors.New("aborted")
 	}
 	return nil
 }
+
+// readLine reads a line one byte at a time, so that no input past the line
+// is consumed from the reader.
+func readLine(in io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := in.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+			line = append(line, b[0])
+		}
+		if err != nil {
+			return string(line), err
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskForConfirmationWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		config    ConfirmConfig
+		opts      []PromptOpt
+		confirmed bool
+	}{
+		{name: "yes", input: "y\n", confirmed: true},
+		{name: "yes in any case", input: "YeS\n", confirmed: true},
+		{name: "no", input: "No\n"},
+		{name: "empty defaults to no", input: "\n"},
+		{name: "empty with default yes", input: "\n", config: ConfirmConfig{Default: true}, confirmed: true},
+		{name: "no input", input: ""},
+		{name: "no newline", input: "yes", confirmed: true},
+		{name: "invalid answers deny", input: "maybe\nyes\n"},
+		{name: "assume yes", input: "", opts: []PromptOpt{WithAssumeYes(true)}, confirmed: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			opts := append([]PromptOpt{WithInputOutput(strings.NewReader(tc.input), &out)}, tc.opts...)
+
+			config := tc.config
+			config.Message = "Delete the cluster?"
+			err := AskForConfirmationWithOptions(&config, opts...)
+			if tc.confirmed {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, "aborted")
+			}
+			prompts := 1
+			if tc.opts != nil {
+				prompts = 0
+			}
+			require.Equal(t, prompts, strings.Count(out.String(), "Delete the cluster?"))
+		})
+	}
+}
+
+func TestAskForConfirmationPrompt(t *testing.T) {
+	var out bytes.Buffer
+	_ = AskForConfirmationWithOptions(&ConfirmConfig{Message: "Continue?"}, WithInputOutput(strings.NewReader("y\n"), &out))
+	require.Equal(t, "? Continue? [y/N]: ", out.String())
+
+	out.Reset()
+	_ = AskForConfirmationWithOptions(&ConfirmConfig{Message: "Continue?", Default: true}, WithInputOutput(strings.NewReader("y\n"), &out))
+	require.Equal(t, "? Continue? [Y/n]: ", out.String())
+}
+
+func TestAskForConfirmationOnlyReadsOneLine(t *testing.T) {
+	in := strings.NewReader("y\nnext input\n")
+	require.NoError(t, AskForConfirmationWithOptions(&ConfirmConfig{Message: "Continue?"}, WithInputOutput(in, &bytes.Buffer{})))
+
+	rest, err := readLine(in)
+	require.NoError(t, err)
+	require.Equal(t, "next input", rest)
+}
+
+func TestYesFlag(t *testing.T) {
+	var confirmErr error
+	cmd := &cobra.Command{
+		Use: "delete",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			confirmErr = AskForConfirmationWithOptions(&ConfirmConfig{Message: "Delete?"}, WithInputOutput(strings.NewReader(""), &bytes.Buffer{}), WithYesFlag(cmd))
+			return nil
+		},
+	}
+	AddYesFlag(cmd)
+
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	require.EqualError(t, confirmErr, "aborted")
+
+	cmd.SetArgs([]string{"-y"})
+	require.NoError(t, cmd.Execute())
+	require.NoError(t, confirmErr)
+
+	// Confirm honours the flag too
+	var confirmed bool
+	require.NoError(t, Confirm(&ConfirmConfig{Message: "Delete?"}, &confirmed, WithYesFlag(cmd)))
+	require.True(t, confirmed)
+}
@@ -5,6 +5,7 @@ package component
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"unicode"
@@ -28,12 +29,22 @@ func NewAurora() auroraPackage.Aurora {
 
 func IsTTYEnabled() bool {
 	ttyEnabled := true
-	if os.Getenv("TANZU_CLI_NO_COLOR") != "" || os.Getenv("NO_COLOR") != "" || strings.EqualFold(os.Getenv("TERM"), "DUMB") || !isatty.IsTerminal(os.Stdout.Fd()) {
+	if colorDisabled() || !isatty.IsTerminal(os.Stdout.Fd()) {
 		ttyEnabled = false
 	}
 	return ttyEnabled
 }
 
+// isColorEnabled returns true if colors can be used when writing to out.
+func isColorEnabled(out io.Writer) bool {
+	return !colorDisabled() && isTerminal(out)
+}
+
+// colorDisabled returns true if colors were disabled through the environment.
+func colorDisabled() bool {
+	return os.Getenv("TANZU_CLI_NO_COLOR") != "" || os.Getenv("NO_COLOR") != "" || strings.EqualFold(os.Getenv("TERM"), "DUMB")
+}
+
 // Rpad adds padding to the right of a string.
 // from https://github.com/spf13/cobra/blob/993cc5372a05240dfd59e3ba952748b36b2cd117/cobra.go#L29
 func Rpad(s string, padding int) string {
@@ -0,0 +1,69 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+// ConfirmConfig is the configuration for a yes/no confirmation.
+type ConfirmConfig struct {
+	// ID identifies the prompt in the answers used in non-interactive mode.
+	// The message is used when it is not set.
+	ID string
+
+	// Message to display to user.
+	Message string
+
+	// Default answer, used when the user only presses enter.
+	Default bool
+
+	// NonInteractiveDefault answers the confirmation with Default in
+	// non-interactive mode when there is no answer for it. The confirmation
+	// fails with an "input required" error otherwise.
+	NonInteractiveDefault bool
+
+	// Help for the prompt.
+	Help string
+}
+
+// Run the confirmation.
+func (p *ConfirmConfig) Run(response interface{}, opts ...PromptOpt) error {
+	return Confirm(p, response, opts...)
+}
+
+// Confirm asks a yes/no question. The response should be a pointer to a
+// bool. The question is answered with yes without asking with the
+// WithAssumeYes and WithYesFlag options.
+func Confirm(p *ConfirmConfig, response interface{}, opts ...PromptOpt) error {
+	options := defaultPromptOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return err
+		}
+	}
+	if options.AssumeYes {
+		return core.WriteAnswer(response, "", true)
+	}
+	if p.NonInteractiveDefault && IsNonInteractive() {
+		answer, ok, err := lookupAnswer(p.ID, p.Message)
+		if err != nil {
+			return err
+		}
+		if !ok || answer == nil {
+			return core.WriteAnswer(response, "", p.Default)
+		}
+	}
+	prompt := translateConfirmConfig(p)
+	return askOne(p.ID, prompt, response, opts)
+}
+
+func translateConfirmConfig(p *ConfirmConfig) survey.Prompt {
+	return &survey.Confirm{
+		Message: p.Message,
+		Default: p.Default,
+		Help:    p.Help,
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// EditorConfig is the configuration for multi-line input, such as a YAML
+// spec, entered in the user's editor.
+type EditorConfig struct {
+	// ID identifies the prompt in the answers used in non-interactive mode.
+	// The message is used when it is not set.
+	ID string
+
+	// Message to display to user.
+	Message string
+
+	// Default content of the file opened in the editor.
+	Default string
+
+	// Help for the prompt.
+	Help string
+
+	// Editor to run, instead of the one set by the VISUAL or EDITOR
+	// environment variables.
+	Editor string
+
+	// FileName is the pattern of the temporary file opened in the editor,
+	// e.g. "*.yaml" to get syntax highlighting.
+	FileName string
+
+	// HideDefault does not show the default content in the prompt.
+	HideDefault bool
+}
+
+// Run the editor prompt.
+func (p *EditorConfig) Run(response interface{}, opts ...PromptOpt) error {
+	return Editor(p, response, opts...)
+}
+
+// Editor opens the user's editor and reads the content of the saved file.
+func Editor(p *EditorConfig, response interface{}, opts ...PromptOpt) error {
+	prompt := translateEditorConfig(p)
+	return askOne(p.ID, prompt, response, opts)
+}
+
+func translateEditorConfig(p *EditorConfig) survey.Prompt {
+	return &survey.Editor{
+		Message:       p.Message,
+		Default:       p.Default,
+		Help:          p.Help,
+		Editor:        p.Editor,
+		FileName:      p.FileName,
+		HideDefault:   p.HideDefault,
+		AppendDefault: true,
+	}
+}
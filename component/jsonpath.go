@@ -0,0 +1,645 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// jsonPath is a parsed kubectl style JSONPath template, e.g.
+// "{range .items[*]}{.metadata.name}{\"\n\"}{end}".
+//
+// The supported syntax is a subset of the kubectl JSONPath support:
+// literal text, child fields (".name" or "['name']"), array indexes and
+// slices ("[0]", "[-1]", "[1:3]"), wildcards ("[*]" or ".*"), recursive
+// descent (".."), filters ("[?(@.status==\"Ready\")]"), quoted string
+// literals and range/end blocks.
+type jsonPath struct {
+	nodes []jsonPathNode
+}
+
+type jsonPathNodeKind int
+
+const (
+	jsonPathText jsonPathNodeKind = iota
+	jsonPathExpression
+	jsonPathRange
+)
+
+type jsonPathNode struct {
+	kind  jsonPathNodeKind
+	text  string
+	path  []jsonPathStep
+	nodes []jsonPathNode
+}
+
+type jsonPathStepKind int
+
+const (
+	stepField jsonPathStepKind = iota
+	stepRecursiveField
+	stepWildcard
+	stepIndex
+	stepSlice
+	stepFilter
+	stepRoot
+)
+
+type jsonPathStep struct {
+	kind   jsonPathStepKind
+	name   string
+	index  int
+	start  *int
+	end    *int
+	filter *jsonPathFilter
+}
+
+type jsonPathFilter struct {
+	path     []jsonPathStep
+	operator string
+	value    interface{}
+}
+
+// parseJSONPath parses a JSONPath template. A template without any "{...}"
+// expression is treated as a single expression, so ".metadata.name" and
+// "{.metadata.name}" are equivalent.
+func parseJSONPath(template string) (*jsonPath, error) {
+	if !strings.Contains(template, "{") {
+		template = "{" + template + "}"
+	}
+
+	nodes, _, err := parseJSONPathNodes(template, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid jsonpath template %q", template)
+	}
+	return &jsonPath{nodes: nodes}, nil
+}
+
+// parseJSONPathNodes parses nodes until the end of the template or, when
+// inRange is set, until the matching "{end}". The unparsed remainder of the
+// template is returned.
+func parseJSONPathNodes(template string, inRange bool) ([]jsonPathNode, string, error) {
+	var nodes []jsonPathNode
+	for template != "" {
+		open := strings.Index(template, "{")
+		if open < 0 {
+			nodes = append(nodes, jsonPathNode{kind: jsonPathText, text: template})
+			template = ""
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, jsonPathNode{kind: jsonPathText, text: template[:open]})
+		}
+
+		closing, err := findClosingBrace(template[open:])
+		if err != nil {
+			return nil, "", err
+		}
+		expr := strings.TrimSpace(template[open+1 : open+closing])
+		template = template[open+closing+1:]
+
+		switch {
+		case expr == "end":
+			if !inRange {
+				return nil, "", errors.New("{end} without matching {range}")
+			}
+			return nodes, template, nil
+		case strings.HasPrefix(expr, "range "):
+			path, err := parseJSONPathSteps(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, "", err
+			}
+			var body []jsonPathNode
+			body, template, err = parseJSONPathNodes(template, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{kind: jsonPathRange, path: path, nodes: body})
+		case strings.HasPrefix(expr, `"`) || strings.HasPrefix(expr, `'`):
+			text, err := unquoteJSONPathString(expr)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{kind: jsonPathText, text: text})
+		default:
+			path, err := parseJSONPathSteps(expr)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{kind: jsonPathExpression, path: path})
+		}
+	}
+
+	if inRange {
+		return nil, "", errors.New("{range} without matching {end}")
+	}
+	return nodes, "", nil
+}
+
+// findClosingBrace returns the index of the brace closing the expression
+// that s starts with, skipping braces within quoted strings.
+func findClosingBrace(s string) (int, error) {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			return 0, errors.New("unexpected '{' within expression")
+		case c == '}':
+			return i, nil
+		}
+	}
+	return 0, errors.New("unclosed expression, missing '}'")
+}
+
+func unquoteJSONPathString(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", errors.Errorf("unterminated string literal %s", s)
+		}
+		s = `"` + strings.ReplaceAll(s[1:len(s)-1], `"`, `\"`) + `"`
+	}
+	text, err := strconv.Unquote(s)
+	if err != nil {
+		return "", errors.Errorf("invalid string literal %s", s)
+	}
+	return text, nil
+}
+
+// parseJSONPathSteps parses a path expression such as ".items[0].name".
+func parseJSONPathSteps(expr string) ([]jsonPathStep, error) {
+	if expr == "" {
+		return nil, errors.New("empty expression")
+	}
+
+	var steps []jsonPathStep
+	s := expr
+	switch {
+	case strings.HasPrefix(s, "$"):
+		steps = append(steps, jsonPathStep{kind: stepRoot})
+		s = s[1:]
+	case strings.HasPrefix(s, "@"):
+		s = s[1:]
+	}
+
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := scanJSONPathName(s[2:])
+			if name == "" {
+				return nil, errors.Errorf("missing field name after '..' in %q", expr)
+			}
+			steps = append(steps, jsonPathStep{kind: stepRecursiveField, name: name})
+			s = rest
+		case strings.HasPrefix(s, ".*"):
+			steps = append(steps, jsonPathStep{kind: stepWildcard})
+			s = s[2:]
+		case strings.HasPrefix(s, "."):
+			name, rest := scanJSONPathName(s[1:])
+			if name != "" {
+				steps = append(steps, jsonPathStep{kind: stepField, name: name})
+			}
+			s = rest
+		case strings.HasPrefix(s, "["):
+			end := findClosingBracket(s)
+			if end < 0 {
+				return nil, errors.Errorf("unclosed '[' in %q", expr)
+			}
+			step, err := parseJSONPathBracket(s[1:end])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid expression %q", expr)
+			}
+			steps = append(steps, step)
+			s = s[end+1:]
+		default:
+			return nil, errors.Errorf("unexpected %q in %q", s, expr)
+		}
+	}
+	return steps, nil
+}
+
+// scanJSONPathName reads a field name, honouring "\." escapes, and returns
+// it together with the rest of the expression.
+func scanJSONPathName(s string) (name, rest string) {
+	var b strings.Builder
+	i := 0
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+			b.WriteByte(s[i])
+			continue
+		}
+		if c == '.' || c == '[' {
+			break
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), s[i:]
+}
+
+func findClosingBracket(s string) int {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseJSONPathBracket(content string) (jsonPathStep, error) {
+	content = strings.TrimSpace(content)
+	switch {
+	case content == "*":
+		return jsonPathStep{kind: stepWildcard}, nil
+	case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+		name, err := unquoteJSONPathString(content)
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		return jsonPathStep{kind: stepField, name: name}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		filter, err := parseJSONPathFilter(content[2 : len(content)-1])
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		return jsonPathStep{kind: stepFilter, filter: filter}, nil
+	case strings.Contains(content, ":"):
+		parts := strings.SplitN(content, ":", 2)
+		step := jsonPathStep{kind: stepSlice}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return jsonPathStep{}, errors.Errorf("invalid slice index %q", part)
+			}
+			if i == 0 {
+				step.start = &n
+			} else {
+				step.end = &n
+			}
+		}
+		return step, nil
+	}
+
+	n, err := strconv.Atoi(content)
+	if err != nil {
+		return jsonPathStep{}, errors.Errorf("invalid array index %q", content)
+	}
+	return jsonPathStep{kind: stepIndex, index: n}, nil
+}
+
+var jsonPathFilterOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseJSONPathFilter(content string) (*jsonPathFilter, error) {
+	filter := &jsonPathFilter{}
+	left := strings.TrimSpace(content)
+	i, op := findJSONPathFilterOperator(content)
+	if i != -1 && !isJSONPathFilterOperator(op) {
+		return nil, errors.Errorf("unsupported operator in filter %q", content)
+	}
+	if op != "" {
+		filter.operator = op
+		left = strings.TrimSpace(content[:i])
+		right := strings.TrimSpace(content[i+len(op):])
+		if j, _ := findJSONPathFilterOperator(right); j != -1 {
+			return nil, errors.Errorf("unsupported operator in filter %q", content)
+		}
+		value, err := parseJSONPathLiteral(right)
+		if err != nil {
+			return nil, err
+		}
+		filter.value = value
+	}
+
+	if !strings.HasPrefix(left, "@") {
+		return nil, errors.Errorf("filter %q must start with '@'", content)
+	}
+	path, err := parseJSONPathSteps(left)
+	if err != nil {
+		return nil, err
+	}
+	filter.path = path
+	return filter, nil
+}
+
+// jsonPathFilterOperatorChars are the characters of the comparison operators
+const jsonPathFilterOperatorChars = "=!<>"
+
+// findJSONPathFilterOperator returns the index of the first run of operator
+// characters of a filter, skipping operators within quoted strings, and the
+// run, which may not be a supported operator, or -1 if there is none.
+func findJSONPathFilterOperator(content string) (int, string) {
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.IndexByte(jsonPathFilterOperatorChars, c) != -1:
+			end := i + 1
+			for end < len(content) && strings.IndexByte(jsonPathFilterOperatorChars, content[end]) != -1 {
+				end++
+			}
+			return i, content[i:end]
+		}
+	}
+	return -1, ""
+}
+
+func isJSONPathFilterOperator(op string) bool {
+	for _, supported := range jsonPathFilterOperators {
+		if op == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func parseJSONPathLiteral(s string) (interface{}, error) {
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`) {
+		return unquoteJSONPathString(s)
+	}
+	if s == "true" || s == "false" {
+		return s == "true", nil
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return nil, errors.Errorf("invalid filter value %q", s)
+	}
+	// Kept as text, to be compared exactly with the numbers of the data
+	return json.Number(s), nil
+}
+
+// execute evaluates the template against data, which is expected to be the
+// generic form produced by decoding JSON.
+func (jp *jsonPath) execute(out io.Writer, data interface{}) error {
+	return executeJSONPathNodes(out, jp.nodes, data, data)
+}
+
+func executeJSONPathNodes(out io.Writer, nodes []jsonPathNode, root, current interface{}) error {
+	for _, node := range nodes {
+		switch node.kind {
+		case jsonPathText:
+			if _, err := io.WriteString(out, node.text); err != nil {
+				return err
+			}
+		case jsonPathExpression:
+			results := evalJSONPath(node.path, root, current)
+			texts := make([]string, 0, len(results))
+			for _, result := range results {
+				text, err := jsonPathValueString(result)
+				if err != nil {
+					return err
+				}
+				texts = append(texts, text)
+			}
+			if _, err := io.WriteString(out, strings.Join(texts, " ")); err != nil {
+				return err
+			}
+		case jsonPathRange:
+			results := evalJSONPath(node.path, root, current)
+			if len(results) == 1 {
+				if list, ok := results[0].([]interface{}); ok {
+					results = list
+				}
+			}
+			for _, result := range results {
+				if err := executeJSONPathNodes(out, node.nodes, root, result); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func evalJSONPath(steps []jsonPathStep, root, current interface{}) []interface{} {
+	values := []interface{}{current}
+	for _, step := range steps {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, evalJSONPathStep(step, root, value)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func evalJSONPathStep(step jsonPathStep, root, value interface{}) []interface{} {
+	switch step.kind {
+	case stepRoot:
+		return []interface{}{root}
+	case stepField:
+		if m, ok := value.(map[string]interface{}); ok {
+			if v, found := m[step.name]; found {
+				return []interface{}{v}
+			}
+		}
+	case stepRecursiveField:
+		var results []interface{}
+		for _, v := range jsonPathDescendants(value) {
+			results = append(results, evalJSONPathStep(jsonPathStep{kind: stepField, name: step.name}, root, v)...)
+		}
+		return results
+	case stepWildcard:
+		return jsonPathChildren(value)
+	case stepIndex:
+		if list, ok := value.([]interface{}); ok {
+			i := step.index
+			if i < 0 {
+				i += len(list)
+			}
+			if i >= 0 && i < len(list) {
+				return []interface{}{list[i]}
+			}
+		}
+	case stepSlice:
+		if list, ok := value.([]interface{}); ok {
+			start, end := 0, len(list)
+			if step.start != nil {
+				start = clampIndex(*step.start, len(list))
+			}
+			if step.end != nil {
+				end = clampIndex(*step.end, len(list))
+			}
+			if start < end {
+				return list[start:end]
+			}
+		}
+	case stepFilter:
+		var results []interface{}
+		for _, child := range jsonPathChildren(value) {
+			if step.filter.matches(root, child) {
+				results = append(results, child)
+			}
+		}
+		return results
+	}
+	return nil
+}
+
+func clampIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+// jsonPathChildren returns the elements of a list, or the values of a map
+// ordered by key.
+func jsonPathChildren(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		children := make([]interface{}, 0, len(v))
+		for _, k := range keys {
+			children = append(children, v[k])
+		}
+		return children
+	}
+	return nil
+}
+
+// jsonPathDescendants returns value and all of its nested values.
+func jsonPathDescendants(value interface{}) []interface{} {
+	results := []interface{}{value}
+	for _, child := range jsonPathChildren(value) {
+		results = append(results, jsonPathDescendants(child)...)
+	}
+	return results
+}
+
+func (f *jsonPathFilter) matches(root, value interface{}) bool {
+	results := evalJSONPath(f.path, root, value)
+	if f.operator == "" {
+		return len(results) > 0
+	}
+	for _, result := range results {
+		if compareJSONPathValues(result, f.operator, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareJSONPathValues compares numbers exactly, whatever their size, and
+// any other values by their text.
+func compareJSONPathValues(left interface{}, operator string, right interface{}) bool {
+	if l, ok := jsonPathNumber(left); ok {
+		if r, ok := jsonPathNumber(right); ok {
+			c := l.Cmp(r)
+			switch operator {
+			case "==":
+				return c == 0
+			case "!=":
+				return c != 0
+			case "<":
+				return c < 0
+			case "<=":
+				return c <= 0
+			case ">":
+				return c > 0
+			case ">=":
+				return c >= 0
+			}
+		}
+	}
+
+	l, r := fmt.Sprint(left), fmt.Sprint(right)
+	switch operator {
+	case "==":
+		return l == r
+	case "!=":
+		return l != r
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+// jsonPathNumber returns the exact value of a number
+func jsonPathNumber(value interface{}) (*big.Rat, bool) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(string(n))
+}
+
+// jsonPathValueString returns the text printed for a value. Strings are
+// printed as is, lists and maps as compact JSON.
+func jsonPathValueString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	bytesJSON, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(bytesJSON), nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath(t *testing.T) {
+	data, err := toGenericJSON(map[string]interface{}{
+		"kind": "List",
+		"items": []map[string]interface{}{
+			{"name": "a", "count": 1, "status": "Ready", "labels": map[string]string{"app.kubernetes.io/name": "x"}},
+			{"name": "b", "count": 2, "status": "Failed"},
+			{"name": "c", "count": 3, "status": "Ready"},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"{.kind}", "List"},
+		{".kind", "List"},
+		{"kind is {.kind}", "kind is List"},
+		{"{$.items[0].name}", "a"},
+		{"{.items[-1].name}", "c"},
+		{"{.items[*].name}", "a b c"},
+		{"{.items[0:2].count}", "1 2"},
+		{"{..status}", "Ready Failed Ready"},
+		{"{.items[0].labels.app\\.kubernetes\\.io/name}", "x"},
+		{"{.items[0].labels['app.kubernetes.io/name']}", "x"},
+		{`{.items[?(@.status=="Ready")].name}`, "a c"},
+		{`{.items[?(@.count>1)].name}`, "b c"},
+		{`{.items[?(@.count>=2)].name}`, "b c"},
+		{`{.items[?(@.status!="a==b")].name}`, "a b c"},
+		{`{.items[?(@.status=='Fail<=ed')].name}`, ""},
+		{`{.items[?(@.labels)].name}`, "a"},
+		{`{range .items[*]}{.name}={.count}{"\n"}{end}`, "a=1\nb=2\nc=3\n"},
+		{`{range .items}[{.name}]{end}`, "[a][b][c]"},
+		{"{.items[1]}", `{"count":2,"name":"b","status":"Failed"}`},
+		{"{.missing}", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			jp, err := parseJSONPath(tt.template)
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, jp.execute(&b, data))
+			require.Equal(t, tt.expected, b.String())
+		})
+	}
+}
+
+func TestJSONPathLargeNumbers(t *testing.T) {
+	data, err := toGenericJSON([]map[string]interface{}{
+		{"id": int64(9007199254740993), "size": uint64(12345678901234567890), "ratio": 0.5},
+		{"id": int64(9007199254740992), "size": 1, "ratio": 1.5},
+	})
+	require.NoError(t, err)
+
+	for template, expected := range map[string]string{
+		"{[*].id}":                                   "9007199254740993 9007199254740992",
+		"{[0].size}":                                 "12345678901234567890",
+		"{[*].ratio}":                                "0.5 1.5",
+		"{[?(@.id==9007199254740993)].size}":         "12345678901234567890",
+		"{[?(@.id>9007199254740992)].id}":            "9007199254740993",
+		"{[?(@.size>=1.2345678901234567e19)].ratio}": "0.5",
+		"{[?(@.ratio<1)].id}":                        "9007199254740993",
+	} {
+		jp, err := parseJSONPath(template)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, jp.execute(&b, data))
+		require.Equal(t, expected, b.String(), template)
+	}
+}
+
+func TestJSONPathInvalid(t *testing.T) {
+	for _, template := range []string{
+		"{.name",
+		"{range .items[*]}{.name}",
+		"{.name}{end}",
+		"{.items[abc]}",
+		"{.items[0}",
+		`{.items[?(.name=="a")]}`,
+		`{"unterminated}`,
+	} {
+		t.Run(template, func(t *testing.T) {
+			_, err := parseJSONPath(template)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid jsonpath template")
+		})
+	}
+}
+
+func TestJSONPathUnsupportedFilterOperator(t *testing.T) {
+	for _, template := range []string{
+		"{.items[?(@.a = 1)]}",
+		"{.items[?(@.a <> 1)]}",
+		"{.items[?(@.a =< 1)]}",
+		"{.items[?(@.a == 1 = 2)]}",
+	} {
+		t.Run(template, func(t *testing.T) {
+			_, err := parseJSONPath(template)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "unsupported operator in filter")
+		})
+	}
+
+	// Operator characters within quoted values are not operators
+	_, err := parseJSONPath(`{.items[?(@.a == "x = <> y")]}`)
+	require.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/pkg/errors"
+)
+
+// MultiSelectConfig is the configuration for a selection of several options.
+type MultiSelectConfig struct {
+	// ID identifies the prompt in the answers used in non-interactive mode.
+	// The message is used when it is not set.
+	ID string
+
+	// Message to display to user.
+	Message string
+
+	// Default options, selected initially.
+	Default []string
+
+	// Options to select from.
+	Options []string
+
+	// Help for the prompt.
+	Help string
+
+	// PageSize defines how many options per page.
+	PageSize int
+
+	// MinSelections is the minimum number of options to select, if set.
+	MinSelections int
+
+	// MaxSelections is the maximum number of options to select, if set.
+	MaxSelections int
+}
+
+// Run the selection.
+func (p *MultiSelectConfig) Run(response interface{}, opts ...PromptOpt) error {
+	return MultiSelect(p, response, opts...)
+}
+
+// MultiSelect several options. The response should be a pointer to a slice
+// of strings.
+func MultiSelect(p *MultiSelectConfig, response interface{}, opts ...PromptOpt) error {
+	if p.MaxSelections > 0 && p.MinSelections > p.MaxSelections {
+		return errors.Errorf("minimum selections %d is greater than maximum selections %d", p.MinSelections, p.MaxSelections)
+	}
+	if p.MinSelections > len(p.Options) {
+		return errors.Errorf("minimum selections %d is greater than the number of options %d", p.MinSelections, len(p.Options))
+	}
+
+	prompt := translateMultiSelectConfig(p)
+	return askOne(p.ID, prompt, response, opts, survey.WithValidator(selectionLimits(p.MinSelections, p.MaxSelections)))
+}
+
+// selectionLimits returns a validator checking the number of selected
+// options. A limit of 0 is not checked.
+func selectionLimits(min, max int) survey.Validator {
+	return func(val interface{}) error {
+		answers, ok := val.([]core.OptionAnswer)
+		if !ok {
+			return errors.Errorf("unexpected answer type %T", val)
+		}
+		if min > 0 && len(answers) < min {
+			return errors.Errorf("select at least %d option(s)", min)
+		}
+		if max > 0 && len(answers) > max {
+			return errors.Errorf("select at most %d option(s)", max)
+		}
+		return nil
+	}
+}
+
+func translateMultiSelectConfig(p *MultiSelectConfig) survey.Prompt {
+	prompt := &survey.MultiSelect{
+		Message:  p.Message,
+		Options:  p.Options,
+		Help:     p.Help,
+		PageSize: p.PageSize,
+	}
+	if len(p.Default) != 0 {
+		prompt.Default = p.Default
+	}
+	return prompt
+}
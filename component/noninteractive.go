@@ -0,0 +1,283 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvNonInteractiveKey is the environment variable enabling the
+	// non-interactive mode when set to a true value, e.g. "true" or "1".
+	EnvNonInteractiveKey = "TANZU_CLI_NON_INTERACTIVE"
+
+	// EnvAnswersFileKey is the environment variable set to the path of a
+	// YAML or JSON file answering prompts in non-interactive mode.
+	EnvAnswersFileKey = "TANZU_CLI_ANSWERS_FILE"
+)
+
+// nonInteractive holds the state of the non-interactive mode set through
+// the API, which takes precedence over the environment.
+var nonInteractive struct {
+	sync.Mutex
+	enabled *bool
+	answers map[string]interface{}
+}
+
+// SetNonInteractive enables or disables the non-interactive mode, whatever
+// the value of the TANZU_CLI_NON_INTERACTIVE environment variable. In
+// non-interactive mode, prompts are answered from the answers, or from their
+// default, and fail with an "input required" error otherwise. Confirmations
+// are only answered from their default when it is explicitly allowed, see
+// ConfirmConfig.NonInteractiveDefault.
+func SetNonInteractive(enabled bool) {
+	nonInteractive.Lock()
+	defer nonInteractive.Unlock()
+
+	nonInteractive.enabled = &enabled
+}
+
+// IsNonInteractive returns true if prompts are answered without asking the
+// user.
+func IsNonInteractive() bool {
+	nonInteractive.Lock()
+	defer nonInteractive.Unlock()
+
+	if nonInteractive.enabled != nil {
+		return *nonInteractive.enabled
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv(EnvNonInteractiveKey))
+	return enabled
+}
+
+// SetAnswers sets the answers used in non-interactive mode, keyed by prompt
+// ID, or by prompt message for prompts without an ID. The answers replace
+// those of the file set by TANZU_CLI_ANSWERS_FILE.
+func SetAnswers(answers map[string]interface{}) {
+	nonInteractive.Lock()
+	defer nonInteractive.Unlock()
+
+	nonInteractive.answers = answers
+}
+
+// LoadAnswersFile sets the answers used in non-interactive mode from a YAML
+// or JSON file mapping prompt IDs to answers.
+func LoadAnswersFile(path string) error {
+	answers, err := readAnswersFile(path)
+	if err != nil {
+		return err
+	}
+	SetAnswers(answers)
+	return nil
+}
+
+func readAnswersFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the answers file")
+	}
+	answers := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the answers file %q", path)
+	}
+	return answers, nil
+}
+
+// lookupAnswer returns the answer for the prompt with the given ID, or with
+// the given message if the ID is not set.
+func lookupAnswer(id, message string) (interface{}, bool, error) {
+	nonInteractive.Lock()
+	defer nonInteractive.Unlock()
+
+	if nonInteractive.answers == nil {
+		nonInteractive.answers = map[string]interface{}{}
+		if path := os.Getenv(EnvAnswersFileKey); path != "" {
+			answers, err := readAnswersFile(path)
+			if err != nil {
+				nonInteractive.answers = nil
+				return nil, false, err
+			}
+			nonInteractive.answers = answers
+		}
+	}
+
+	key := id
+	if key == "" {
+		key = message
+	}
+	answer, ok := nonInteractive.answers[key]
+	return answer, ok, nil
+}
+
+// inputRequiredError is returned in non-interactive mode for prompts with
+// no answer and no default.
+func inputRequiredError(message string) error {
+	return errors.Errorf("input required: %s", message)
+}
+
+// answerNonInteractively writes the answer of a prompt to response, without
+// asking the user. The answer is transformed and validated like an answer
+// given by the user.
+func answerNonInteractively(id string, prompt survey.Prompt, response interface{}, options *PromptOptions, surveyOpts []survey.AskOpt) error {
+	message, answer, err := nonInteractiveAnswer(id, prompt)
+	if err != nil {
+		return err
+	}
+
+	answer, err = checkAnswer(answer, options, surveyOpts)
+	if err != nil {
+		return errors.Wrapf(err, "invalid answer for %q", message)
+	}
+	return core.WriteAnswer(response, "", answer)
+}
+
+// nonInteractiveAnswer returns the message of the prompt, and its answer
+// from the answers or from its default, as the prompt would return it.
+func nonInteractiveAnswer(id string, prompt survey.Prompt) (string, interface{}, error) {
+	switch p := prompt.(type) {
+	case *survey.Input:
+		answer, err := textAnswer(id, p.Message, p.Default)
+		return p.Message, answer, err
+	case *survey.Password:
+		answer, err := textAnswer(id, p.Message, "")
+		return p.Message, answer, err
+	case *survey.Editor:
+		answer, err := textAnswer(id, p.Message, p.Default)
+		return p.Message, answer, err
+	case *survey.Select:
+		answer, err := selectAnswer(id, p.Message, p.Options, p.Default)
+		return p.Message, answer, err
+	case *survey.MultiSelect:
+		answer, err := multiSelectAnswer(id, p.Message, p.Options, p.Default)
+		return p.Message, answer, err
+	case *survey.Confirm:
+		// The default of a confirmation is false when not set, so it is
+		// not used without an answer
+		answer, err := confirmAnswer(id, p.Message, nil)
+		return p.Message, answer, err
+	}
+	return "", nil, errors.Errorf("prompt type %T is not supported in non-interactive mode", prompt)
+}
+
+func textAnswer(id, message, defaultValue string) (string, error) {
+	answer, ok, err := lookupAnswer(id, message)
+	switch {
+	case err != nil:
+		return "", err
+	case ok && answer != nil:
+		return fmt.Sprintf("%v", answer), nil
+	case defaultValue != "":
+		return defaultValue, nil
+	}
+	return "", inputRequiredError(message)
+}
+
+func selectAnswer(id, message string, options []string, defaultValue interface{}) (core.OptionAnswer, error) {
+	answer, ok, err := lookupAnswer(id, message)
+	if err != nil {
+		return core.OptionAnswer{}, err
+	}
+	if !ok || answer == nil {
+		answer = defaultValue
+	}
+	if answer == nil || answer == "" {
+		return core.OptionAnswer{}, inputRequiredError(message)
+	}
+	return optionAnswer(message, options, answer)
+}
+
+func multiSelectAnswer(id, message string, options []string, defaultValue interface{}) ([]core.OptionAnswer, error) {
+	answer, ok, err := lookupAnswer(id, message)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || answer == nil {
+		answer = defaultValue
+	}
+
+	var values []interface{}
+	switch a := answer.(type) {
+	case nil:
+		return nil, inputRequiredError(message)
+	case []interface{}:
+		values = a
+	case []string:
+		for _, v := range a {
+			values = append(values, v)
+		}
+	case []int:
+		for _, v := range a {
+			values = append(values, v)
+		}
+	default:
+		values = []interface{}{a}
+	}
+
+	answers := []core.OptionAnswer{}
+	for _, value := range values {
+		option, err := optionAnswer(message, options, value)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, option)
+	}
+	return answers, nil
+}
+
+// optionAnswer returns the option matching an answer given as the option
+// text, or else as the option index.
+func optionAnswer(message string, options []string, answer interface{}) (core.OptionAnswer, error) {
+	value := fmt.Sprintf("%v", answer)
+	for i, option := range options {
+		if option == value {
+			return core.OptionAnswer{Value: option, Index: i}, nil
+		}
+	}
+	if index, ok := answer.(int); ok && index >= 0 && index < len(options) {
+		return core.OptionAnswer{Value: options[index], Index: index}, nil
+	}
+	return core.OptionAnswer{}, errors.Errorf("invalid answer %q for %q, expected one of %s", value, message, strings.Join(options, ", "))
+}
+
+// confirmAnswer returns the answer to a yes/no question, or the default if
+// there is one.
+func confirmAnswer(id, message string, defaultValue *bool) (bool, error) {
+	answer, ok, err := lookupAnswer(id, message)
+	if err != nil {
+		return false, err
+	}
+	if !ok || answer == nil {
+		if defaultValue == nil {
+			return false, inputRequiredError(message)
+		}
+		return *defaultValue, nil
+	}
+	return confirmValue(message, answer)
+}
+
+// confirmValue returns the answer to a yes/no question, given as a bool or
+// as "y", "yes", "n", "no", "true" or "false" in any case.
+func confirmValue(message string, answer interface{}) (bool, error) {
+	switch a := answer.(type) {
+	case bool:
+		return a, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(a)) {
+		case "y", "yes", "true":
+			return true, nil
+		case "n", "no", "false":
+			return false, nil
+		}
+	}
+	return false, errors.Errorf("invalid answer %v for %q, expected yes or no", answer, message)
+}
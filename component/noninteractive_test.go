@@ -0,0 +1,122 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setNonInteractive enables the non-interactive mode with the given answers
+// for the duration of the test.
+func setNonInteractive(t *testing.T, answers map[string]interface{}) {
+	nonInteractive.Lock()
+	enabled, previous := nonInteractive.enabled, nonInteractive.answers
+	nonInteractive.Unlock()
+	t.Cleanup(func() {
+		nonInteractive.Lock()
+		nonInteractive.enabled, nonInteractive.answers = enabled, previous
+		nonInteractive.Unlock()
+	})
+
+	SetNonInteractive(true)
+	SetAnswers(answers)
+}
+
+func TestIsNonInteractive(t *testing.T) {
+	setNonInteractive(t, nil)
+
+	nonInteractive.enabled = nil
+	t.Setenv(EnvNonInteractiveKey, "")
+	require.False(t, IsNonInteractive())
+	t.Setenv(EnvNonInteractiveKey, "true")
+	require.True(t, IsNonInteractive())
+
+	SetNonInteractive(false)
+	require.False(t, IsNonInteractive())
+}
+
+func TestNonInteractivePrompts(t *testing.T) {
+	setNonInteractive(t, map[string]interface{}{
+		"name":                     " my-context ",
+		"Endpoint:":                "api.example.com",
+		"plugins":                  []interface{}{"cluster", 2},
+		"delete":                   "yes",
+		"Delete all the clusters?": true,
+	})
+
+	var name, endpoint, kind, spec string
+	require.NoError(t, Prompt(&PromptConfig{ID: "name", Message: "Context name:"}, &name,
+		WithTransformer(strings.TrimSpace), WithValidator(ValidContextName)))
+	require.Equal(t, "my-context", name)
+
+	require.NoError(t, Ask(&QuestionConfig{Message: "Endpoint:"}, &endpoint))
+	require.Equal(t, "api.example.com", endpoint)
+
+	require.NoError(t, Select(&SelectConfig{ID: "kind", Message: "Kind:", Options: []string{"tkg", "tmc"}, Default: "tmc"}, &kind))
+	require.Equal(t, "tmc", kind)
+
+	require.NoError(t, Editor(&EditorConfig{ID: "spec", Message: "Spec:", Default: "kind: Cluster"}, &spec))
+	require.Equal(t, "kind: Cluster", spec)
+
+	var plugins []string
+	require.NoError(t, MultiSelect(&MultiSelectConfig{ID: "plugins", Message: "Plugins:", Options: []string{"cluster", "package", "secret"}}, &plugins))
+	require.Equal(t, []string{"cluster", "secret"}, plugins)
+
+	var confirmed bool
+	require.NoError(t, Confirm(&ConfirmConfig{ID: "delete", Message: "Delete the cluster?"}, &confirmed))
+	require.True(t, confirmed)
+	require.NoError(t, AskForConfirmation("Delete all the clusters?"))
+}
+
+func TestNonInteractiveInputRequired(t *testing.T) {
+	setNonInteractive(t, map[string]interface{}{"kind": "aks"})
+
+	var response string
+	require.EqualError(t, Prompt(&PromptConfig{Message: "Context name:"}, &response), "input required: Context name:")
+	require.EqualError(t, Prompt(&PromptConfig{Message: "Password:", Sensitive: true, Default: "ignored"}, &response), "input required: Password:")
+	require.EqualError(t, Select(&SelectConfig{Message: "Kind:", Options: []string{"tkg"}}, &response), "input required: Kind:")
+	require.EqualError(t, Select(&SelectConfig{ID: "kind", Message: "Kind:", Options: []string{"tkg", "tmc"}}, &response), `invalid answer "aks" for "Kind:", expected one of tkg, tmc`)
+	require.EqualError(t, AskForConfirmation("Delete?"), "input required: Delete?")
+
+	var confirmed bool
+	require.EqualError(t, Confirm(&ConfirmConfig{ID: "delete", Message: "Delete the cluster?"}, &confirmed), "input required: Delete the cluster?")
+	require.EqualError(t, Confirm(&ConfirmConfig{Message: "Delete the cluster?", Default: true}, &confirmed), "input required: Delete the cluster?")
+	require.NoError(t, Confirm(&ConfirmConfig{Message: "Delete the cluster?", Default: true, NonInteractiveDefault: true}, &confirmed))
+	require.True(t, confirmed)
+	require.NoError(t, AskForConfirmationWithOptions(&ConfirmConfig{Message: "Delete?", Default: true, NonInteractiveDefault: true}))
+
+	var plugins []string
+	require.EqualError(t, MultiSelect(&MultiSelectConfig{Message: "Plugins:", Options: []string{"a", "b"}, MinSelections: 2, Default: []string{"a"}}, &plugins),
+		`invalid answer for "Plugins:": select at least 2 option(s)`)
+}
+
+func TestNonInteractiveAnswersFile(t *testing.T) {
+	setNonInteractive(t, nil)
+	nonInteractive.answers = nil
+
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: from-file\nconfirm: n\n"), 0o600))
+	t.Setenv(EnvAnswersFileKey, path)
+
+	var name string
+	require.NoError(t, Prompt(&PromptConfig{ID: "name", Message: "Name:"}, &name))
+	require.Equal(t, "from-file", name)
+
+	var confirmed bool
+	require.NoError(t, Confirm(&ConfirmConfig{ID: "confirm", Message: "Sure?", Default: true}, &confirmed))
+	require.False(t, confirmed)
+
+	jsonPath := filepath.Join(t.TempDir(), "answers.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"name": "from-json"}`), 0o600))
+	require.NoError(t, LoadAnswersFile(jsonPath))
+	require.NoError(t, Prompt(&PromptConfig{ID: "name", Message: "Name:"}, &name))
+	require.Equal(t, "from-json", name)
+
+	require.Error(t, LoadAnswersFile(filepath.Join(t.TempDir(), "missing.yaml")))
+}
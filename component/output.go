@@ -38,46 +38,114 @@ const (
 	JSONOutputType OutputType = "json"
 	// ListTableOutputType specified output should be in a list table format.
 	ListTableOutputType OutputType = "listtable"
+	// CSVOutputType specifies output should be in comma separated values format.
+	CSVOutputType OutputType = "csv"
+	// TSVOutputType specifies output should be in tab separated values format.
+	TSVOutputType OutputType = "tsv"
+	// JSONPathOutputType specifies output should be rendered using a JSONPath
+	// template, given as "jsonpath=<template>".
+	JSONPathOutputType OutputType = "jsonpath"
+	// GoTemplateOutputType specifies output should be rendered using a Go
+	// template, given as "go-template=<template>".
+	GoTemplateOutputType OutputType = "go-template"
+	// GoTemplateFileOutputType specifies output should be rendered using a Go
+	// template read from a file, given as "go-template-file=<path>".
+	GoTemplateFileOutputType OutputType = "go-template-file"
+	// MarkdownOutputType specifies output should be in GitHub flavored
+	// markdown table format.
+	MarkdownOutputType OutputType = "markdown"
+	// HTMLOutputType specifies output should be in html table format.
+	HTMLOutputType OutputType = "html"
+	// CustomColumnsOutputType specifies output of an object should be in
+	// table format with the given columns, as "custom-columns=NAME:.path,...".
+	CustomColumnsOutputType OutputType = "custom-columns"
 )
 
 // outputwriter is our internal implementation.
 type outputwriter struct {
 	out          io.Writer
 	keys         []string
-	values       [][]string
+	values       [][]interface{}
 	outputFormat OutputType
+	outputParam  string
+	formatters   map[string]ColumnFormatter
+	// handoffSpinner is stopped before any output is written
+	handoffSpinner      OutputWriterSpinner
+	streamingSampleSize int
+	sortKeys            []SortKey
+	filters             []ColumnFilter
+	columns             []string
+	widthPolicies       map[string]ColumnWidthPolicy
+	maxWidth            int
+	styles              map[string]CellStyleFunc
+	color               *bool
+	listLayout          bool
 }
 
+// OutputWriterOption is an option for configuring an OutputWriter.
+type OutputWriterOption func(*outputwriter)
+
 // NewOutputWriter gets a new instance of our output writer.
 func NewOutputWriter(output io.Writer, outputFormat string, headers ...string) OutputWriter {
+	return NewOutputWriterWithOptions(output, outputFormat, nil, headers...)
+}
+
+// NewOutputWriterWithOptions gets a new instance of our output writer
+// configured with the given options.
+func NewOutputWriterWithOptions(output io.Writer, outputFormat string, opts []OutputWriterOption, headers ...string) OutputWriter {
 	// Initialize the output writer that we use under the covers
 	ow := &outputwriter{}
 	ow.out = output
-	ow.outputFormat = OutputType(outputFormat)
+	ow.outputFormat, ow.outputParam = parseOutputFormat(outputFormat)
 	ow.keys = headers
+	ow.applyOptions(opts)
 
 	return ow
 }
 
+func (ow *outputwriter) applyOptions(opts []OutputWriterOption) {
+	for _, opt := range opts {
+		opt(ow)
+	}
+}
+
+// WithSpinner hands over a running spinner to the writer, which stops it
+// before writing any output.
+func WithSpinner(spinner OutputWriterSpinner) OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.handoffSpinner = spinner
+	}
+}
+
 // SetKeys sets the values to use as the keys for the output values.
 func (ow *outputwriter) SetKeys(headerKeys ...string) {
 	// Overwrite whatever was used in initialization
 	ow.keys = headerKeys
 }
 
-// AddRow appends a new row to our table.
+// AddRow appends a new row to our table. The values are kept as is for the
+// structured output formats, and converted to strings for the text formats.
 func (ow *outputwriter) AddRow(items ...interface{}) {
-	row := []string{}
-
-	// Make sure all values are ultimately strings
-	for _, item := range items {
-		row = append(row, fmt.Sprintf("%v", item))
-	}
+	row := make([]interface{}, len(items))
+	copy(row, items)
 	ow.values = append(ow.values, row)
 }
 
 // Render emits the generated table to the output once ready
 func (ow *outputwriter) Render() {
+	ow.stopSpinner()
+	if err := ow.checkColumns(ow.keys); err != nil {
+		fmt.Fprintln(ow.out, err)
+		return
+	}
+
+	// Render the rows remaining once sorted, filtered and projected
+	view := *ow
+	view.keys, view.values = ow.rowView()
+	view.render()
+}
+
+func (ow *outputwriter) render() {
 	switch ow.outputFormat {
 	case JSONOutputType:
 		renderJSON(ow.out, ow.dataStruct())
@@ -85,30 +153,80 @@ func (ow *outputwriter) Render() {
 		renderYAML(ow.out, ow.dataStruct())
 	case ListTableOutputType:
 		renderListTable(ow)
+	case CSVOutputType:
+		renderCSV(ow.out, ow.keys, ow.stringValues(false), csvSeparator)
+	case TSVOutputType:
+		renderCSV(ow.out, ow.keys, ow.stringValues(false), tsvSeparator)
+	case JSONPathOutputType, GoTemplateOutputType, GoTemplateFileOutputType:
+		renderTemplate(ow.out, ow.outputFormat, ow.outputParam, ow.dataStruct())
+	case MarkdownOutputType:
+		renderMarkdown(ow)
+	case HTMLOutputType:
+		renderHTML(ow)
 	default:
 		renderTable(ow)
 	}
 }
 
-func (ow *outputwriter) dataStruct() []map[string]string {
-	data := []map[string]string{}
-	keys := ow.keys
-	for i, k := range keys {
-		keys[i] = strings.ToLower(strings.ReplaceAll(k, " ", "_"))
+func (ow *outputwriter) dataStruct() []map[string]interface{} {
+	data := []map[string]interface{}{}
+	for _, itemValues := range ow.values {
+		data = append(data, ow.rowData(itemValues))
 	}
 
+	return data
+}
+
+// stringValues returns the rows with all values converted to strings. When
+// formatted is set, the column formatters are applied.
+func (ow *outputwriter) stringValues(formatted bool) [][]string {
+	values := make([][]string, 0, len(ow.values))
 	for _, itemValues := range ow.values {
-		item := map[string]string{}
-		for i, value := range itemValues {
-			if i == len(keys) {
+		values = append(values, ow.stringRow(itemValues, formatted))
+	}
+	return values
+}
+
+// stringRow converts the values of a row to strings. When formatted is set,
+// the column formatters are applied.
+func (ow *outputwriter) stringRow(itemValues []interface{}, formatted bool) []string {
+	row := make([]string, 0, len(itemValues))
+	for i, value := range itemValues {
+		if formatted && i < len(ow.keys) {
+			if formatter := ow.formatters[strings.ToLower(ow.keys[i])]; formatter != nil {
+				row = append(row, formatter(value))
 				continue
 			}
-			item[keys[i]] = value
 		}
-		data = append(data, item)
+		// Make sure all values are ultimately strings
+		row = append(row, fmt.Sprintf("%v", value))
 	}
+	return row
+}
 
-	return data
+// rowData returns a row as a map keyed by the lower case header names, as
+// written by the structured output formats.
+func (ow *outputwriter) rowData(itemValues []interface{}) map[string]interface{} {
+	item := map[string]interface{}{}
+	for i, value := range itemValues {
+		if i >= len(ow.keys) {
+			break
+		}
+		item[dataKey(ow.keys[i])] = value
+	}
+	return item
+}
+
+// stopSpinner stops the spinner handed over with WithSpinner, if any.
+func (ow *outputwriter) stopSpinner() {
+	if ow.handoffSpinner != nil {
+		ow.handoffSpinner.StopSpinner()
+		ow.handoffSpinner = nil
+	}
+}
+
+func dataKey(header string) string {
+	return strings.ToLower(strings.ReplaceAll(header, " ", "_"))
 }
 
 // objectwriter is our internal implementation.
@@ -116,6 +234,7 @@ type objectwriter struct {
 	out          io.Writer
 	data         interface{}
 	outputFormat OutputType
+	outputParam  string
 }
 
 // NewObjectWriter gets a new instance of our output writer.
@@ -124,7 +243,7 @@ func NewObjectWriter(output io.Writer, outputFormat string, data interface{}) Ou
 	obw := &objectwriter{}
 	obw.out = output
 	obw.data = data
-	obw.outputFormat = OutputType(outputFormat)
+	obw.outputFormat, obw.outputParam = parseOutputFormat(outputFormat)
 
 	return obw
 }
@@ -148,6 +267,14 @@ func (obw *objectwriter) Render() {
 		renderJSON(obw.out, obw.data)
 	case YAMLOutputType:
 		renderYAML(obw.out, obw.data)
+	case CSVOutputType:
+		renderObjectCSV(obw.out, obw.data, csvSeparator)
+	case TSVOutputType:
+		renderObjectCSV(obw.out, obw.data, tsvSeparator)
+	case JSONPathOutputType, GoTemplateOutputType, GoTemplateFileOutputType:
+		renderTemplate(obw.out, obw.outputFormat, obw.outputParam, obw.data)
+	case TableOutputType, ListTableOutputType, MarkdownOutputType, HTMLOutputType, CustomColumnsOutputType, "":
+		renderObjectTable(obw)
 	default:
 		fmt.Fprintf(obw.out, "Invalid output format: %v\n", obw.outputFormat)
 	}
@@ -185,9 +312,11 @@ func renderListTable(ow *outputwriter) {
 		}
 	}
 
+	rows := ow.stringValues(true)
+	ow.styleRows(rows, ow.values)
 	for i, header := range ow.keys {
 		row := []string{}
-		for _, data := range ow.values {
+		for _, data := range rows {
 			if i >= len(data) {
 				// There are more headers than values, leave it blank
 				continue
@@ -203,14 +332,18 @@ func renderListTable(ow *outputwriter) {
 // renderTable prints output as a table
 func renderTable(ow *outputwriter) {
 	// Drop values if there aren't as many as the headers
+	rows := ow.stringValues(true)
 	headerLength := len(ow.keys)
-	for i, values := range ow.values {
+	for i, values := range rows {
 		if len(values) <= headerLength {
 			continue
 		}
 
-		ow.values[i] = values[:headerLength]
+		rows[i] = values[:headerLength]
 	}
+	fitted := ow.fitTableRows(rows)
+	ow.styleRows(rows, ow.values)
+
 	table := tablewriter.NewWriter(ow.out)
 	table.SetBorder(false)
 	table.SetCenterSeparator("")
@@ -221,7 +354,12 @@ func renderTable(ow *outputwriter) {
 	table.SetHeaderLine(false)
 	table.SetColWidth(colWidth)
 	table.SetTablePadding("\t\t")
+	if fitted {
+		// Cells have already been truncated or wrapped to fit, and must not
+		// be wrapped at the default column width
+		table.SetAutoWrapText(false)
+	}
 	table.SetHeader(ow.keys)
-	table.AppendBulk(ow.values)
+	table.AppendBulk(rows)
 	table.Render()
 }
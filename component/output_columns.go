@@ -0,0 +1,182 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const noneColumnValue = "<none>"
+
+// ObjectColumn describes a column used when an object is rendered as a table
+// or list table by the object writer.
+type ObjectColumn struct {
+	// Header of the column.
+	Header string
+
+	// Path is a JSONPath expression selecting the value of the column,
+	// e.g. ".metadata.name". Fields are referred to by their json names.
+	Path string
+}
+
+// TableColumnsProvider is implemented by types that declare the default
+// columns used to render them as a table or list table with NewObjectWriter.
+// When the object writer is given a slice, the element type is checked for
+// this interface.
+type TableColumnsProvider interface {
+	TableColumns() []ObjectColumn
+}
+
+// parseCustomColumns parses a custom columns specification of the form
+// "NAME:.path,STATUS:.status.phase".
+func parseCustomColumns(spec string) ([]ObjectColumn, error) {
+	if spec == "" {
+		return nil, errors.Errorf("custom-columns format specified but no columns given, use %s=NAME:.path,...", CustomColumnsOutputType)
+	}
+
+	var columns []ObjectColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, path, found := strings.Cut(part, ":")
+		header, path = strings.TrimSpace(header), strings.TrimSpace(path)
+		if !found || header == "" || path == "" {
+			return nil, errors.Errorf("invalid custom-columns specification %q, expected NAME:.path", part)
+		}
+		if _, err := parseJSONPath(path); err != nil {
+			return nil, errors.Wrapf(err, "invalid path for custom column %s", header)
+		}
+		columns = append(columns, ObjectColumn{Header: header, Path: path})
+	}
+	return columns, nil
+}
+
+// defaultObjectColumns returns the columns declared by the type of data, or
+// by its element type when data is a slice.
+func defaultObjectColumns(data interface{}) []ObjectColumn {
+	if provider, ok := data.(TableColumnsProvider); ok {
+		return provider.TableColumns()
+	}
+
+	t := reflect.TypeOf(data)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+		return nil
+	}
+
+	// Check the zero value of the element type, so that both value and
+	// pointer receivers are found
+	elemType := t.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if provider, ok := reflect.New(elemType).Interface().(TableColumnsProvider); ok {
+		return provider.TableColumns()
+	}
+	return nil
+}
+
+// objectTable converts an object, or a slice of objects, into a header and a
+// set of rows using the given columns. If no columns are given the default
+// columns of the type are used, falling back to one column per flattened
+// field.
+func objectTable(data interface{}, columns []ObjectColumn) (keys []string, values [][]string, err error) {
+	if columns == nil {
+		columns = defaultObjectColumns(data)
+	}
+	if columns == nil {
+		return flattenObject(data)
+	}
+
+	paths := make([]*jsonPath, len(columns))
+	for i, column := range columns {
+		keys = append(keys, column.Header)
+		if paths[i], err = parseJSONPath(column.Path); err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid path for column %s", column.Header)
+		}
+	}
+
+	for _, item := range objectItems(data) {
+		generic, err := toGenericJSON(item.Interface())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		row := make([]string, len(columns))
+		for i, path := range paths {
+			if row[i], err = columnValue(path, generic); err != nil {
+				return nil, nil, err
+			}
+		}
+		values = append(values, row)
+	}
+	return keys, values, nil
+}
+
+// columnValue evaluates a column path against an item. Missing values are
+// shown as "<none>" and multiple values are separated by commas.
+func columnValue(path *jsonPath, item interface{}) (string, error) {
+	if len(path.nodes) != 1 || path.nodes[0].kind != jsonPathExpression {
+		var b bytes.Buffer
+		err := path.execute(&b, item)
+		return b.String(), err
+	}
+
+	results := evalJSONPath(path.nodes[0].path, item, item)
+	if len(results) == 0 {
+		return noneColumnValue, nil
+	}
+	texts := make([]string, 0, len(results))
+	for _, result := range results {
+		text, err := jsonPathValueString(result)
+		if err != nil {
+			return "", err
+		}
+		texts = append(texts, text)
+	}
+	return strings.Join(texts, ","), nil
+}
+
+// renderObjectTable prints an object, or a slice of objects, as a table, a
+// list table, or a markdown or html table.
+func renderObjectTable(obw *objectwriter) {
+	var columns []ObjectColumn
+	if obw.outputFormat == CustomColumnsOutputType {
+		var err error
+		if columns, err = parseCustomColumns(obw.outputParam); err != nil {
+			fmt.Fprintln(obw.out, err)
+			return
+		}
+	}
+
+	keys, values, err := objectTable(obw.data, columns)
+	if err != nil {
+		fmt.Fprintln(obw.out, err)
+		return
+	}
+
+	ow := &outputwriter{out: obw.out, keys: keys}
+	for _, row := range values {
+		items := make([]interface{}, len(row))
+		for i, value := range row {
+			items[i] = value
+		}
+		ow.AddRow(items...)
+	}
+	switch obw.outputFormat {
+	case ListTableOutputType:
+		renderListTable(ow)
+	case MarkdownOutputType:
+		renderMarkdown(ow)
+	case HTMLOutputType:
+		renderHTML(ow)
+	default:
+		renderTable(ow)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	csvSeparator = ','
+	tsvSeparator = '\t'
+)
+
+// renderCSV prints output as separator delimited records, using the keys as
+// the header record. Fields containing the separator, quotes or newlines are
+// quoted and escaped.
+func renderCSV(out io.Writer, keys []string, values [][]string, separator rune) {
+	w := csv.NewWriter(out)
+	w.Comma = separator
+
+	if err := w.Write(keys); err != nil {
+		fmt.Fprint(out, err)
+		return
+	}
+	for _, row := range values {
+		record := make([]string, len(keys))
+		copy(record, row)
+		if err := w.Write(record); err != nil {
+			fmt.Fprint(out, err)
+			return
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		fmt.Fprint(out, err)
+	}
+}
+
+// renderObjectCSV prints an object, or a slice of objects, as separator
+// delimited records with one column per flattened field.
+func renderObjectCSV(out io.Writer, data interface{}, separator rune) {
+	keys, values, err := flattenObject(data)
+	if err != nil {
+		fmt.Fprint(out, err)
+		return
+	}
+	renderCSV(out, keys, values, separator)
+}
+
+// flattenObject converts an object, or a slice of objects, into a header and
+// a set of rows. Nested struct fields and map entries are flattened into
+// dotted column names, e.g. "metadata.name".
+func flattenObject(data interface{}) (keys []string, values [][]string, err error) {
+	items := objectItems(data)
+
+	seen := map[string]bool{}
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		row := map[string]string{}
+		if err := flattenValue("", item, row, &keys, seen); err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(keys))
+		for i, k := range keys {
+			record[i] = row[k]
+		}
+		values = append(values, record)
+	}
+	return keys, values, nil
+}
+
+// objectItems returns the elements of data if it is a slice or an array, or
+// data itself otherwise.
+func objectItems(data interface{}) []reflect.Value {
+	v := indirectValue(reflect.ValueOf(data))
+
+	var items []reflect.Value
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i))
+		}
+	case reflect.Invalid:
+	default:
+		items = append(items, v)
+	}
+	return items
+}
+
+// flattenValue adds the flattened representation of v to row, recording any
+// newly discovered column name in keys.
+func flattenValue(prefix string, v reflect.Value, row map[string]string, keys *[]string, seen map[string]bool) error {
+	addColumn := func(name, value string) {
+		if name == "" {
+			name = "value"
+		}
+		if !seen[name] {
+			seen[name] = true
+			*keys = append(*keys, name)
+		}
+		row[name] = value
+	}
+
+	if isScalarValue(v) {
+		s, err := scalarString(v)
+		if err != nil {
+			return err
+		}
+		addColumn(prefix, s)
+		return nil
+	}
+
+	v = indirectValue(v)
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			// Only exported fields and the promoted fields of embedded
+			// structs are serialized
+			if field.PkgPath != "" && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+				continue
+			}
+			name, inline := fieldName(field)
+			if name == "-" {
+				continue
+			}
+			childPrefix := prefix
+			if !inline {
+				childPrefix = joinFieldPath(prefix, name)
+			}
+			if err := flattenValue(childPrefix, v.Field(i), row, keys, seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		mapKeys := v.MapKeys()
+		sort.Slice(mapKeys, func(i, j int) bool {
+			return valueString(mapKeys[i]) < valueString(mapKeys[j])
+		})
+		for _, k := range mapKeys {
+			childPrefix := joinFieldPath(prefix, valueString(k))
+			if err := flattenValue(childPrefix, v.MapIndex(k), row, keys, seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Invalid:
+		addColumn(prefix, "")
+	default:
+		// The values reached through unexported fields cannot be marshaled
+		if !v.CanInterface() {
+			addColumn(prefix, fmt.Sprintf("%v", v))
+			return nil
+		}
+		// Lists and any other composite values are kept as compact JSON
+		bytesJSON, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		addColumn(prefix, string(bytesJSON))
+	}
+	return nil
+}
+
+// valueString returns the string representation of a value, which may have
+// been reached through unexported fields.
+func valueString(v reflect.Value) string {
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// isScalarValue reports whether v should be rendered as a single field.
+func isScalarValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	if v.CanInterface() {
+		switch v.Interface().(type) {
+		case fmt.Stringer, encoding.TextMarshaler:
+			return !(v.Kind() == reflect.Ptr && v.IsNil())
+		}
+	}
+	switch indirectValue(v).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// scalarString returns the string representation of a scalar value.
+func scalarString(v reflect.Value) (string, error) {
+	if !v.CanInterface() {
+		return fmt.Sprintf("%v", v), nil
+	}
+	switch t := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		b, err := t.MarshalText()
+		return string(b), err
+	case fmt.Stringer:
+		return t.String(), nil
+	}
+	return fmt.Sprintf("%v", indirectValue(v).Interface()), nil
+}
+
+// indirectValue dereferences pointers and interfaces until a concrete value
+// is reached.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// fieldName returns the name a struct field is serialized with, and whether
+// the field is an embedded struct whose fields should be inlined.
+func fieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = strings.Split(field.Tag.Get("yaml"), ",")[0]
+	}
+	if name != "" {
+		return name, false
+	}
+	return field.Name, field.Anonymous
+}
+
+func joinFieldPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ColumnFormatter converts the value of a column to the text shown in table
+// and list table output. Structured output formats always use the original
+// value.
+type ColumnFormatter func(value interface{}) string
+
+// WithColumnFormatter sets the formatter used for the column with the given
+// header. Headers are matched case insensitively.
+func WithColumnFormatter(header string, formatter ColumnFormatter) OutputWriterOption {
+	return func(ow *outputwriter) {
+		if ow.formatters == nil {
+			ow.formatters = map[string]ColumnFormatter{}
+		}
+		ow.formatters[strings.ToLower(header)] = formatter
+	}
+}
+
+// DurationFormatter formats a time.Duration, or the time elapsed since a
+// time.Time, as a short human readable duration such as "5m3s" or "2d4h".
+func DurationFormatter(value interface{}) string {
+	switch v := value.(type) {
+	case time.Duration:
+		return humanDuration(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return humanDuration(time.Since(v))
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return DurationFormatter(*v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// TimestampFormatter returns a formatter that formats a time.Time using the
+// given layout, e.g. time.RFC3339.
+func TimestampFormatter(layout string) ColumnFormatter {
+	return func(value interface{}) string {
+		switch v := value.(type) {
+		case time.Time:
+			if v.IsZero() {
+				return ""
+			}
+			return v.Format(layout)
+		case *time.Time:
+			if v == nil || v.IsZero() {
+				return ""
+			}
+			return v.Format(layout)
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// ByteSizeFormatter formats a number of bytes using binary units, e.g.
+// "512 B", "1.5 KiB" or "3.0 GiB". Values of any numeric type, and strings
+// holding a number, are formatted, as they are when sorting and filtering.
+func ByteSizeFormatter(value interface{}) string {
+	size, ok := numericValue(value)
+	if s, isString := value.(string); isString {
+		size, ok = parseNumber(s)
+	}
+	if !ok {
+		return fmt.Sprintf("%v", value)
+	}
+
+	const unit = 1024
+	if size < unit && size > -unit {
+		return fmt.Sprintf("%d B", int64(size))
+	}
+	const units = "KMGTPE"
+	exp := 0
+	// Larger sizes are written in the last unit
+	for (size >= unit*unit || size <= -unit*unit) && exp < len(units)-1 {
+		size /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", size/unit, units[exp])
+}
+
+// humanDuration returns a short representation of a duration using at most
+// two units.
+func humanDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Second)
+
+	days := int64(d / (24 * time.Hour))
+	hours := int64(d/time.Hour) % 24
+	minutes := int64(d/time.Minute) % 60
+	seconds := int64(d/time.Second) % 60
+
+	switch {
+	case days > 0:
+		return joinDurationUnits(days, "d", hours, "h")
+	case hours > 0:
+		return joinDurationUnits(hours, "h", minutes, "m")
+	case minutes > 0:
+		return joinDurationUnits(minutes, "m", seconds, "s")
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+func joinDurationUnits(major int64, majorUnit string, minor int64, minorUnit string) string {
+	if minor == 0 {
+		return fmt.Sprintf("%d%s", major, majorUnit)
+	}
+	return fmt.Sprintf("%d%s%d%s", major, majorUnit, minor, minorUnit)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationFormatter(t *testing.T) {
+	require.Equal(t, "0s", DurationFormatter(0*time.Second))
+	require.Equal(t, "59s", DurationFormatter(59*time.Second))
+	require.Equal(t, "5m", DurationFormatter(5*time.Minute))
+	require.Equal(t, "5m3s", DurationFormatter(5*time.Minute+3*time.Second))
+	require.Equal(t, "2h30m", DurationFormatter(150*time.Minute))
+	require.Equal(t, "3d4h", DurationFormatter(76*time.Hour))
+	require.Equal(t, "1h", DurationFormatter(time.Now().Add(-time.Hour)))
+	require.Equal(t, "", DurationFormatter(time.Time{}))
+	require.Equal(t, "n/a", DurationFormatter("n/a"))
+}
+
+func TestTimestampFormatter(t *testing.T) {
+	formatter := TimestampFormatter(time.RFC3339)
+	ts := time.Date(2023, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	require.Equal(t, "2023-03-01T10:30:00Z", formatter(ts))
+	require.Equal(t, "2023-03-01T10:30:00Z", formatter(&ts))
+	require.Equal(t, "", formatter(time.Time{}))
+	require.Equal(t, "never", formatter("never"))
+}
+
+func TestByteSizeFormatter(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KiB"},
+		{int8(100), "100 B"},
+		{int16(2048), "2.0 KiB"},
+		{int32(1536), "1.5 KiB"},
+		{int64(1536), "1.5 KiB"},
+		{uint(1536), "1.5 KiB"},
+		{uint8(200), "200 B"},
+		{uint16(4096), "4.0 KiB"},
+		{uint32(1024 * 1024), "1.0 MiB"},
+		{uint64(3 * 1024 * 1024 * 1024), "3.0 GiB"},
+		{float32(1536), "1.5 KiB"},
+		{1e25, "8673617.4 EiB"},
+		{"1536", "1.5 KiB"},
+		{" 2048 ", "2.0 KiB"},
+		{"unknown", "unknown"},
+		{nil, "<nil>"},
+		{true, "true"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T(%v)", tt.value, tt.value), func(t *testing.T) {
+			require.Equal(t, tt.expected, ByteSizeFormatter(tt.value))
+		})
+	}
+}
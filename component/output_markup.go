@@ -0,0 +1,143 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// WithListLayout renders markdown and html output as a definition list, with
+// one entry per header listing the values of all rows, like the list table
+// output.
+func WithListLayout() OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.listLayout = true
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`|`, `\|`,
+	`*`, `\*`,
+	`_`, `\_`,
+	"`", "\\`",
+	"<", "&lt;",
+	">", "&gt;",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// escapeMarkdown escapes text for use in a markdown table cell.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// escapeHTML escapes text for use in an html element.
+func escapeHTML(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+}
+
+// renderMarkdown prints output as a GitHub flavored markdown table, or as a
+// list of headers and values when the list layout is used.
+func renderMarkdown(ow *outputwriter) {
+	rows := ow.stringValues(true)
+	if ow.listLayout {
+		for i, header := range ow.keys {
+			fmt.Fprintf(ow.out, "- **%s**: %s\n", escapeMarkdown(header), strings.Join(markdownColumn(rows, i), ", "))
+		}
+		return
+	}
+
+	writeMarkdownRow(ow.out, ow.keys, len(ow.keys))
+	separators := make([]string, len(ow.keys))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	fmt.Fprintf(ow.out, "| %s |\n", strings.Join(separators, " | "))
+	for _, row := range rows {
+		writeMarkdownRow(ow.out, row, len(ow.keys))
+	}
+}
+
+// markdownColumn returns the escaped values of a column.
+func markdownColumn(rows [][]string, i int) []string {
+	values := []string{}
+	for _, row := range rows {
+		if i < len(row) {
+			values = append(values, escapeMarkdown(row[i]))
+		}
+	}
+	return values
+}
+
+// writeMarkdownRow writes a markdown table row with the given number of
+// cells, adding or dropping cells as needed.
+func writeMarkdownRow(out io.Writer, row []string, cells int) {
+	escaped := make([]string, cells)
+	for i := range escaped {
+		if i < len(row) {
+			escaped[i] = escapeMarkdown(row[i])
+		}
+	}
+	fmt.Fprintf(out, "| %s |\n", strings.Join(escaped, " | "))
+}
+
+// renderHTML prints output as an html table, or as a definition list when
+// the list layout is used.
+func renderHTML(ow *outputwriter) {
+	rows := ow.stringValues(true)
+	if ow.listLayout {
+		fmt.Fprintln(ow.out, "<dl>")
+		for i, header := range ow.keys {
+			fmt.Fprintf(ow.out, "%s<dt>%s</dt>\n", indentation, escapeHTML(header))
+			for _, row := range rows {
+				if i < len(row) {
+					fmt.Fprintf(ow.out, "%s<dd>%s</dd>\n", indentation, escapeHTML(row[i]))
+				}
+			}
+		}
+		fmt.Fprintln(ow.out, "</dl>")
+		return
+	}
+
+	writeHTMLTableStart(ow.out, ow.keys)
+	for _, row := range rows {
+		writeHTMLRow(ow.out, row, len(ow.keys))
+	}
+	writeHTMLTableEnd(ow.out)
+}
+
+func writeHTMLTableStart(out io.Writer, keys []string) {
+	fmt.Fprintln(out, "<table>")
+	fmt.Fprintf(out, "%s<thead>\n", indentation)
+	fmt.Fprintf(out, "%s<tr>", strings.Repeat(indentation, 2))
+	for _, key := range keys {
+		fmt.Fprintf(out, "<th>%s</th>", escapeHTML(key))
+	}
+	fmt.Fprintln(out, "</tr>")
+	fmt.Fprintf(out, "%s</thead>\n", indentation)
+	fmt.Fprintf(out, "%s<tbody>\n", indentation)
+}
+
+// writeHTMLRow writes an html table row with the given number of cells,
+// adding or dropping cells as needed.
+func writeHTMLRow(out io.Writer, row []string, cells int) {
+	fmt.Fprintf(out, "%s<tr>", strings.Repeat(indentation, 2))
+	for i := 0; i < cells; i++ {
+		cell := ""
+		if i < len(row) {
+			cell = row[i]
+		}
+		fmt.Fprintf(out, "<td>%s</td>", escapeHTML(cell))
+	}
+	fmt.Fprintln(out, "</tr>")
+}
+
+func writeHTMLTableEnd(out io.Writer) {
+	fmt.Fprintf(out, "%s</tbody>\n", indentation)
+	fmt.Fprintln(out, "</table>")
+}
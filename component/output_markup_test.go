@@ -0,0 +1,96 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutputWriterMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriter(&b, string(MarkdownOutputType), "Name", "Description")
+	tab.AddRow("my_cluster", "a | b")
+	tab.AddRow("other", "line one\nline two <br>")
+	tab.AddRow("short")
+	tab.Render()
+
+	expected := "| Name | Description |\n" +
+		"| --- | --- |\n" +
+		"| my\\_cluster | a \\| b |\n" +
+		"| other | line one<br>line two &lt;br&gt; |\n" +
+		"| short |  |\n"
+	require.Equal(t, expected, b.String())
+}
+
+func TestNewOutputWriterMarkdownListLayout(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(MarkdownOutputType), []OutputWriterOption{WithListLayout()}, "Name", "Age")
+	tab.AddRow("John", 30)
+	tab.AddRow("Bob", 45)
+	tab.Render()
+
+	require.Equal(t, "- **Name**: John, Bob\n- **Age**: 30, 45\n", b.String())
+}
+
+func TestNewOutputWriterHTML(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriter(&b, string(HTMLOutputType), "Name", "Notes")
+	tab.AddRow("<script>", "a & b\nc")
+	tab.Render()
+
+	expected := "<table>\n" +
+		"  <thead>\n" +
+		"    <tr><th>Name</th><th>Notes</th></tr>\n" +
+		"  </thead>\n" +
+		"  <tbody>\n" +
+		"    <tr><td>&lt;script&gt;</td><td>a &amp; b<br>c</td></tr>\n" +
+		"  </tbody>\n" +
+		"</table>\n"
+	require.Equal(t, expected, b.String())
+}
+
+func TestNewOutputWriterHTMLListLayout(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(HTMLOutputType), []OutputWriterOption{WithListLayout()}, "Name", "Age")
+	tab.AddRow("John", 30)
+	tab.AddRow("Bob", 45)
+	tab.Render()
+
+	expected := "<dl>\n" +
+		"  <dt>Name</dt>\n" +
+		"  <dd>John</dd>\n" +
+		"  <dd>Bob</dd>\n" +
+		"  <dt>Age</dt>\n" +
+		"  <dd>30</dd>\n" +
+		"  <dd>45</dd>\n" +
+		"</dl>\n"
+	require.Equal(t, expected, b.String())
+}
+
+func TestObjectWriterMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, string(MarkdownOutputType), []testStruct{{Name: "hal", Namespace: "Jupiter"}})
+	out.Render()
+
+	require.Equal(t, "| name | spacename |\n| --- | --- |\n| hal | Jupiter |\n", b.String())
+}
+
+func TestStreamingOutputWriterMarkup(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(MarkdownOutputType), nil, "Name")
+	sow.AddRow("one")
+	require.Equal(t, "| Name |\n| --- |\n| one |\n", b.String())
+	sow.Render()
+	require.Equal(t, "| Name |\n| --- |\n| one |\n", b.String())
+
+	b.Reset()
+	sow = NewStreamingOutputWriter(&b, string(HTMLOutputType), nil, "Name")
+	sow.AddRow("one")
+	require.Equal(t, "<table>\n  <thead>\n    <tr><th>Name</th></tr>\n  </thead>\n  <tbody>\n    <tr><td>one</td></tr>\n", b.String())
+	sow.Render()
+	require.Equal(t, "<table>\n  <thead>\n    <tr><th>Name</th></tr>\n  </thead>\n  <tbody>\n    <tr><td>one</td></tr>\n  </tbody>\n</table>\n", b.String())
+}
@@ -0,0 +1,397 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SortKey describes a column to sort rows by.
+type SortKey struct {
+	// Column is the header of the column to sort by.
+	Column string
+
+	// Descending sorts the rows in descending order.
+	Descending bool
+
+	// Numeric compares the values as numbers. Values of numeric types are
+	// always compared as numbers.
+	Numeric bool
+}
+
+// FilterOperator is the comparison used by a ColumnFilter.
+type FilterOperator string
+
+const (
+	// FilterEquals matches rows where the column equals the value.
+	FilterEquals FilterOperator = "="
+	// FilterNotEquals matches rows where the column does not equal the value.
+	FilterNotEquals FilterOperator = "!="
+	// FilterLessThan matches rows where the column is less than the value.
+	FilterLessThan FilterOperator = "<"
+	// FilterGreaterThan matches rows where the column is greater than the value.
+	FilterGreaterThan FilterOperator = ">"
+	// FilterLessOrEqual matches rows where the column is less than or equal to the value.
+	FilterLessOrEqual FilterOperator = "<="
+	// FilterGreaterOrEqual matches rows where the column is greater than or equal to the value.
+	FilterGreaterOrEqual FilterOperator = ">="
+)
+
+// ColumnFilter is a predicate on the value of a named column. Values of
+// numeric types are compared as numbers when the filter value is a number,
+// and the other values are compared as strings unless Numeric is set.
+type ColumnFilter struct {
+	Column   string
+	Operator FilterOperator
+	Value    string
+
+	// Numeric compares the values parsing as numbers as numbers.
+	Numeric bool
+}
+
+// WithSortBy sorts the rows by the given keys, in order of precedence.
+// Rows that compare equal keep the order they were added in.
+func WithSortBy(keys ...SortKey) OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.sortKeys = append(ow.sortKeys, keys...)
+	}
+}
+
+// WithFilters only outputs the rows matching all the given filters. A filter
+// on a column which is not one of the headers fails the output.
+func WithFilters(filters ...ColumnFilter) OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.filters = append(ow.filters, filters...)
+	}
+}
+
+// WithColumns only outputs the given columns, in the given order. Sorting
+// and filtering can still use the columns that are not output. A column
+// which is not one of the headers fails the output.
+func WithColumns(columns ...string) OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.columns = columns
+	}
+}
+
+// ParseSortBy parses a comma separated list of columns to sort by, as given
+// to a --sort-by flag. A column prefixed with "-" is sorted in descending
+// order, and a column suffixed with ":num" is compared numerically, e.g.
+// "status,-age:num".
+func ParseSortBy(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key := SortKey{}
+		if strings.HasPrefix(part, "-") {
+			key.Descending = true
+			part = part[1:]
+		}
+		if strings.HasSuffix(part, ":num") {
+			key.Numeric = true
+			part = strings.TrimSuffix(part, ":num")
+		}
+		if part == "" {
+			return nil, errors.Errorf("invalid sort key in %q", spec)
+		}
+		key.Column = part
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParseFilters parses a comma separated list of field selector style
+// predicates, as given to a --field-selector flag, e.g.
+// "status=Ready,count>=1". The supported operators are "=", "==", "!=", "<",
+// ">", "<=" and ">=". A column suffixed with ":num" is compared numerically,
+// e.g. "age:num>10".
+func ParseFilters(selector string) ([]ColumnFilter, error) {
+	var filters []ColumnFilter
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		// The operator is the first one in the predicate, the longest one
+		// if several start at the same position
+		i := strings.IndexAny(part, "!=<>")
+		if i <= 0 {
+			return nil, errors.Errorf("invalid filter %q, expected <column><operator><value>", part)
+		}
+		op := part[i : i+1]
+		for _, long := range []string{"!=", "==", "<=", ">="} {
+			if strings.HasPrefix(part[i:], long) {
+				op = long
+			}
+		}
+		if op == "!" {
+			return nil, errors.Errorf("invalid filter %q, expected <column><operator><value>", part)
+		}
+		operator := FilterOperator(op)
+		if operator == "==" {
+			operator = FilterEquals
+		}
+		filter := ColumnFilter{
+			Column:   strings.TrimSpace(part[:i]),
+			Operator: operator,
+			Value:    strings.TrimSpace(part[i+len(op):]),
+		}
+		if strings.HasSuffix(filter.Column, ":num") {
+			filter.Numeric = true
+			filter.Column = strings.TrimSuffix(filter.Column, ":num")
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// columnIndex returns the index of the column with the given header, or -1.
+// Columns are matched case insensitively, by header or by the key used in
+// the structured output formats.
+func columnIndex(keys []string, column string) int {
+	for i, key := range keys {
+		if strings.EqualFold(key, column) || strings.EqualFold(dataKey(key), column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkColumns returns an error if the filters, sort keys or selected columns
+// refer to columns which are not in keys.
+func (ow *outputwriter) checkColumns(keys []string) error {
+	check := func(column, use string) error {
+		if columnIndex(keys, column) == -1 {
+			return errors.Errorf("unknown column %q in %s, the columns are: %s", column, use, strings.Join(keys, ", "))
+		}
+		return nil
+	}
+	for _, filter := range ow.filters {
+		if err := check(filter.Column, "filter"); err != nil {
+			return err
+		}
+	}
+	for _, key := range ow.sortKeys {
+		if err := check(key.Column, "sort keys"); err != nil {
+			return err
+		}
+	}
+	for _, column := range ow.columns {
+		if err := check(column, "selected columns"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowView returns the keys and rows to output once the filters, sort keys
+// and column selection have been applied.
+func (ow *outputwriter) rowView() ([]string, [][]interface{}) {
+	rows := make([][]interface{}, 0, len(ow.values))
+	for _, row := range ow.values {
+		if ow.matchesFilters(ow.keys, row) {
+			rows = append(rows, row)
+		}
+	}
+	ow.sortRows(ow.keys, rows)
+
+	keys, indexes := ow.projectedColumns(ow.keys)
+	if indexes != nil {
+		for i, row := range rows {
+			rows[i] = projectRow(row, indexes)
+		}
+	}
+	return keys, rows
+}
+
+// matchesFilters returns true if the row matches all the filters.
+func (ow *outputwriter) matchesFilters(keys []string, row []interface{}) bool {
+	for _, filter := range ow.filters {
+		i := columnIndex(keys, filter.Column)
+		var value interface{}
+		if i >= 0 && i < len(row) {
+			value = row[i]
+		}
+		if !filter.matches(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f ColumnFilter) matches(value interface{}) bool {
+	text := ""
+	if value != nil {
+		text = fmt.Sprintf("%v", value)
+	}
+
+	cmp := 0
+	left, leftNumeric := numericValue(value)
+	right, rightErr := strconv.ParseFloat(f.Value, 64)
+	if !leftNumeric && f.Numeric && rightErr == nil && value != nil {
+		left, leftNumeric = parseNumber(value)
+	}
+	if leftNumeric && rightErr == nil {
+		cmp = compareFloats(left, right)
+	} else {
+		cmp = strings.Compare(text, f.Value)
+	}
+
+	switch f.Operator {
+	case FilterEquals, "==":
+		return cmp == 0
+	case FilterNotEquals:
+		return cmp != 0
+	case FilterLessThan:
+		return cmp < 0
+	case FilterGreaterThan:
+		return cmp > 0
+	case FilterLessOrEqual:
+		return cmp <= 0
+	case FilterGreaterOrEqual:
+		return cmp >= 0
+	}
+	return false
+}
+
+// sortRows sorts the rows in place using the sort keys.
+func (ow *outputwriter) sortRows(keys []string, rows [][]interface{}) {
+	if len(ow.sortKeys) == 0 {
+		return
+	}
+
+	indexes := make([]int, len(ow.sortKeys))
+	for i, key := range ow.sortKeys {
+		indexes[i] = columnIndex(keys, key.Column)
+	}
+
+	sort.SliceStable(rows, func(a, b int) bool {
+		for i, key := range ow.sortKeys {
+			cmp := compareValues(cellValue(rows[a], indexes[i]), cellValue(rows[b], indexes[i]), key.Numeric)
+			if cmp == 0 {
+				continue
+			}
+			if key.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// projectedColumns returns the selected columns and their indexes in keys.
+// The indexes are nil when no columns were selected.
+func (ow *outputwriter) projectedColumns(keys []string) ([]string, []int) {
+	if ow.columns == nil {
+		return keys, nil
+	}
+
+	projected := []string{}
+	indexes := []int{}
+	for _, column := range ow.columns {
+		if i := columnIndex(keys, column); i >= 0 {
+			projected = append(projected, keys[i])
+			indexes = append(indexes, i)
+		}
+	}
+	return projected, indexes
+}
+
+func projectRow(row []interface{}, indexes []int) []interface{} {
+	projected := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		projected[i] = cellValue(row, index)
+	}
+	return projected
+}
+
+func cellValue(row []interface{}, i int) interface{} {
+	if i < 0 || i >= len(row) {
+		return nil
+	}
+	return row[i]
+}
+
+// compareValues compares two cell values, as numbers if both are of numeric
+// types or numeric is set and both parse as numbers, and as strings
+// otherwise. Missing values sort first.
+func compareValues(a, b interface{}, numeric bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	fa, aNumeric := numericValue(a)
+	fb, bNumeric := numericValue(b)
+	if numeric && !aNumeric {
+		fa, aNumeric = parseNumber(a)
+	}
+	if numeric && !bNumeric {
+		fb, bNumeric = parseNumber(b)
+	}
+	if aNumeric && bNumeric {
+		return compareFloats(fa, fb)
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// numericValue returns the value as a float64 if it is of a numeric type.
+func numericValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func parseNumber(value interface{}) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(fmt.Sprintf("%v", value)), 64)
+	return f, err == nil
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -4,13 +4,8 @@
 package component
 
 import (
-	"fmt"
+	"context"
 	"io"
-	"os"
-	"time"
-
-	"github.com/briandowns/spinner"
-	"github.com/mattn/go-isatty"
 )
 
 // OutputWriterSpinner is OutputWriter augmented with a spinner.
@@ -24,26 +19,31 @@ type OutputWriterSpinner interface {
 type outputwriterspinner struct {
 	outputwriter
 	spinnerText string
-	spinner     *spinner.Spinner
+	spinner     Spinner
 }
 
 // NewOutputWriterWithSpinner returns implementation of OutputWriterSpinner.
+// The spinner is written to output, and only started if output is a
+// terminal.
 func NewOutputWriterWithSpinner(output io.Writer, outputFormat, spinnerText string, startSpinner bool, headers ...string) (OutputWriterSpinner, error) {
+	return NewOutputWriterWithSpinnerOptions(context.Background(), output, outputFormat, spinnerText, startSpinner, []SpinnerOption{WithSpinnerWriter(output)}, headers...)
+}
+
+// NewOutputWriterWithSpinnerOptions returns implementation of
+// OutputWriterSpinner using a spinner configured with the given options. The
+// spinner is written to os.Stderr unless WithSpinnerWriter is used, and
+// stops when ctx is cancelled.
+func NewOutputWriterWithSpinnerOptions(ctx context.Context, output io.Writer, outputFormat, spinnerText string, startSpinner bool, opts []SpinnerOption, headers ...string) (OutputWriterSpinner, error) {
 	ows := &outputwriterspinner{}
 	ows.out = output
-	ows.outputFormat = OutputType(outputFormat)
+	ows.outputFormat, ows.outputParam = parseOutputFormat(outputFormat)
 	ows.keys = headers
-	if ows.outputFormat != JSONOutputType && ows.outputFormat != YAMLOutputType {
+	if ows.outputFormat != JSONOutputType && ows.outputFormat != YAMLOutputType && !isTemplateOutputType(ows.outputFormat) {
 		ows.spinnerText = spinnerText
-		ows.spinner = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-		if err := ows.spinner.Color("bgBlack", "bold", "fgWhite"); err != nil {
-			return nil, err
-		}
-		ows.spinner.Suffix = fmt.Sprintf(" %s", spinnerText)
+		ows.spinner = NewSpinner(ctx, spinnerText, opts...)
 
-		// Start the spinner only if attached to terminal
-		attachedToTerminal := isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
-		if startSpinner && attachedToTerminal {
+		// The spinner only starts if attached to terminal
+		if startSpinner {
 			ows.spinner.Start()
 		}
 	}
@@ -52,17 +52,13 @@ func NewOutputWriterWithSpinner(output io.Writer, outputFormat, spinnerText stri
 
 // RenderWithSpinner will stop spinner and render the output
 func (ows *outputwriterspinner) RenderWithSpinner() {
-	if ows.spinner != nil && ows.spinner.Active() {
-		ows.spinner.Stop()
-		fmt.Fprintln(ows.out)
-	}
+	ows.StopSpinner()
 	ows.Render()
 }
 
 // stop spinner
 func (ows *outputwriterspinner) StopSpinner() {
-	if ows.spinner != nil && ows.spinner.Active() {
+	if ows.spinner != nil {
 		ows.spinner.Stop()
-		fmt.Fprintln(ows.out)
 	}
 }
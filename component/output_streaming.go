@@ -0,0 +1,434 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultStreamingSampleSize is the number of rows used to work out the
+// column widths of streamed table output.
+const DefaultStreamingSampleSize = 20
+
+// StreamingOutputWriter is an OutputWriter that writes rows as they are
+// added instead of buffering them until Render is called. It is meant for
+// commands that page through many resources or follow a watch.
+//
+// Rows are written as newline delimited JSON for json, as a multi-document
+// stream for yaml, and as table lines for table output. The table column
+// widths are worked out from the first rows and stay fixed afterwards.
+type StreamingOutputWriter interface {
+	OutputWriter
+
+	// Flush writes any rows held back to work out the table column widths.
+	Flush()
+}
+
+// streamingoutputwriter is our internal implementation.
+type streamingoutputwriter struct {
+	outputwriter
+	mutex      sync.Mutex
+	sampleSize int
+	widths     []int
+	rowCount   int
+	headerDone bool
+	csvWriter  *csv.Writer
+	tmpl       outputTemplate
+	// tmplErr is the error parsing the template, or the first error executing
+	// it, after which no more rows are written
+	tmplErr      error
+	tmplReported bool
+	// rawKeys are the keys before the columns are selected
+	rawKeys []string
+	indexes []int
+	// columnsFailed is set once an unknown column was reported
+	columnsFailed bool
+}
+
+// WithStreamingSampleSize sets the number of rows a StreamingOutputWriter
+// holds back to work out the table column widths. It defaults to
+// DefaultStreamingSampleSize.
+func WithStreamingSampleSize(n int) OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.streamingSampleSize = n
+	}
+}
+
+// NewStreamingOutputWriter gets a new instance of a streaming output writer.
+func NewStreamingOutputWriter(output io.Writer, outputFormat string, opts []OutputWriterOption, headers ...string) StreamingOutputWriter {
+	sow := &streamingoutputwriter{}
+	sow.out = output
+	sow.outputFormat, sow.outputParam = parseOutputFormat(outputFormat)
+	sow.applyOptions(opts)
+	sow.setKeys(headers)
+
+	sow.sampleSize = sow.streamingSampleSize
+	if sow.sampleSize <= 0 {
+		sow.sampleSize = DefaultStreamingSampleSize
+	}
+	switch sow.outputFormat {
+	case JSONPathOutputType, GoTemplateOutputType, GoTemplateFileOutputType:
+		// The template is parsed once for all the rows
+		sow.tmpl, sow.tmplErr = newOutputTemplate(sow.outputFormat, sow.outputParam)
+	}
+	return sow
+}
+
+// SetKeys sets the values to use as the keys for the output values. It has
+// no effect on the header once the first row has been written.
+func (sow *streamingoutputwriter) SetKeys(headerKeys ...string) {
+	sow.mutex.Lock()
+	defer sow.mutex.Unlock()
+
+	sow.setKeys(headerKeys)
+}
+
+func (sow *streamingoutputwriter) setKeys(headerKeys []string) {
+	sow.rawKeys = headerKeys
+	sow.keys, sow.indexes = sow.projectedColumns(headerKeys)
+}
+
+// AddRow writes a row, or holds it back while the table column widths are
+// being worked out. Rows not matching the filters are dropped. Sorting is not
+// supported when streaming, the error is written instead of the rows.
+func (sow *streamingoutputwriter) AddRow(items ...interface{}) {
+	sow.mutex.Lock()
+	defer sow.mutex.Unlock()
+
+	if sow.checkColumnsOnce() || !sow.matchesFilters(sow.rawKeys, items) {
+		return
+	}
+	row := make([]interface{}, len(items))
+	copy(row, items)
+	if sow.indexes != nil {
+		row = projectRow(row, sow.indexes)
+	}
+
+	if sow.isTable() && sow.widths == nil {
+		sow.values = append(sow.values, row)
+		if len(sow.values) >= sow.sampleSize {
+			sow.flush()
+		}
+		return
+	}
+	sow.writeRow(row)
+}
+
+// Flush writes any rows held back to work out the table column widths.
+func (sow *streamingoutputwriter) Flush() {
+	sow.mutex.Lock()
+	defer sow.mutex.Unlock()
+
+	sow.flush()
+}
+
+// Render ends the stream, writing any rows that were held back.
+func (sow *streamingoutputwriter) Render() {
+	sow.mutex.Lock()
+	defer sow.mutex.Unlock()
+
+	sow.stopSpinner()
+	if sow.checkColumnsOnce() {
+		return
+	}
+	sow.flush()
+	switch sow.outputFormat {
+	case JSONPathOutputType, GoTemplateOutputType, GoTemplateFileOutputType:
+		sow.reportTemplateError()
+	case CSVOutputType, TSVOutputType:
+		sow.writeCSVHeader()
+	case MarkdownOutputType:
+		sow.writeMarkdownHeader()
+	case HTMLOutputType:
+		sow.writeHTMLHeader()
+		writeHTMLTableEnd(sow.out)
+	}
+}
+
+// checkColumnsOnce returns true if sort keys were set, or if the filters or
+// selected columns refer to unknown columns, writing the error the first time
+// only.
+func (sow *streamingoutputwriter) checkColumnsOnce() bool {
+	if sow.columnsFailed {
+		return true
+	}
+	err := sow.checkColumns(sow.rawKeys)
+	if err == nil && len(sow.sortKeys) > 0 {
+		err = errors.New("sorting is not supported by streaming output")
+	}
+	if err != nil {
+		sow.columnsFailed = true
+		sow.stopSpinner()
+		fmt.Fprintln(sow.out, err)
+		return true
+	}
+	return false
+}
+
+func (sow *streamingoutputwriter) isTable() bool {
+	switch sow.outputFormat {
+	case JSONOutputType, YAMLOutputType, ListTableOutputType, CSVOutputType, TSVOutputType,
+		JSONPathOutputType, GoTemplateOutputType, GoTemplateFileOutputType,
+		MarkdownOutputType, HTMLOutputType:
+		return false
+	}
+	return true
+}
+
+func (sow *streamingoutputwriter) flush() {
+	sow.stopSpinner()
+	if sow.isTable() {
+		if sow.widths == nil {
+			sow.widths = sow.columnWidths()
+			if maxWidth := sow.tableWidth(); maxWidth > 0 {
+				sow.widths = sow.fitColumnWidths(sow.widths, maxWidth, func(policy ColumnWidthPolicy) bool {
+					return policy == ColumnTruncate || policy == ColumnWrap
+				})
+			}
+		}
+		sow.writeTableHeader()
+	}
+
+	pending := sow.values
+	sow.values = nil
+	for _, row := range pending {
+		sow.writeRow(row)
+	}
+}
+
+// columnWidths works out the table column widths from the header and the
+// rows held back so far.
+func (sow *streamingoutputwriter) columnWidths() []int {
+	widths := make([]int, len(sow.keys))
+	for i, key := range sow.keys {
+		widths[i] = runewidth.StringWidth(key)
+	}
+	for _, row := range sow.stringValues(true) {
+		for i := 0; i < len(row) && i < len(widths); i++ {
+			if w := runewidth.StringWidth(row[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	return widths
+}
+
+func (sow *streamingoutputwriter) writeTableHeader() {
+	if sow.headerDone {
+		return
+	}
+	sow.headerDone = true
+
+	header := make([]string, len(sow.keys))
+	for i, key := range sow.keys {
+		header[i] = strings.ToUpper(key)
+	}
+	sow.writeTableLine(header, nil)
+}
+
+// writeTableLine writes a row of the table using the fixed column widths.
+// Cells wider than their column are truncated or wrapped if the column has
+// a truncate or wrap policy, and overflow otherwise. The cells are styled
+// using the values of the row, if given.
+func (sow *streamingoutputwriter) writeTableLine(cells []string, values []interface{}) {
+	styles := make([]CellStyle, len(sow.widths))
+	if values != nil && sow.colorEnabled() {
+		for i := 0; i < len(styles) && i < len(sow.keys) && i < len(values); i++ {
+			if style := sow.styles[strings.ToLower(sow.keys[i])]; style != nil {
+				styles[i] = style(values[i])
+			}
+		}
+	}
+
+	lines := make([][]string, len(sow.widths))
+	height := 1
+	for i, width := range sow.widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		policy := ColumnNoShrink
+		if i < len(sow.keys) {
+			if p := sow.widthPolicy(sow.keys[i]); p == ColumnTruncate || p == ColumnWrap {
+				policy = p
+			}
+		}
+		lines[i] = fitCell(cell, width, policy)
+		if len(lines[i]) > height {
+			height = len(lines[i])
+		}
+	}
+
+	for l := 0; l < height; l++ {
+		var b strings.Builder
+		for i, width := range sow.widths {
+			cell := ""
+			if l < len(lines[i]) {
+				cell = lines[i][l]
+			}
+			b.WriteString(indentation)
+			b.WriteString(applyCellStyle(cell, styles[i]))
+			if pad := width - runewidth.StringWidth(cell); pad > 0 {
+				b.WriteString(strings.Repeat(" ", pad))
+			}
+		}
+		b.WriteString(indentation)
+		fmt.Fprintln(sow.out, b.String())
+	}
+}
+
+func (sow *streamingoutputwriter) writeRow(row []interface{}) {
+	sow.stopSpinner()
+	defer func() { sow.rowCount++ }()
+
+	switch sow.outputFormat {
+	case JSONOutputType:
+		bytesJSON, err := json.Marshal(sow.rowData(row))
+		if err != nil {
+			fmt.Fprintln(sow.out, err)
+			return
+		}
+		fmt.Fprintln(sow.out, string(bytesJSON))
+	case YAMLOutputType:
+		yamlInBytes, err := yaml.Marshal(sow.rowData(row))
+		if err != nil {
+			fmt.Fprintln(sow.out, err)
+			return
+		}
+		fmt.Fprintf(sow.out, "---\n%s", yamlInBytes)
+	case ListTableOutputType:
+		sow.writeListTableRow(row)
+	case CSVOutputType, TSVOutputType:
+		sow.writeCSVRow(row)
+	case JSONPathOutputType, GoTemplateOutputType, GoTemplateFileOutputType:
+		sow.writeTemplateRow(row)
+	case MarkdownOutputType:
+		sow.writeMarkdownHeader()
+		writeMarkdownRow(sow.out, sow.stringRow(row, true), len(sow.keys))
+	case HTMLOutputType:
+		sow.writeHTMLHeader()
+		writeHTMLRow(sow.out, sow.stringRow(row, true), len(sow.keys))
+	default:
+		sow.writeTableLine(sow.stringRow(row, true), row)
+	}
+}
+
+// writeListTableRow writes a row as a block of "KEY: value" lines, with
+// blocks separated by a blank line.
+func (sow *streamingoutputwriter) writeListTableRow(row []interface{}) {
+	headerLength := 10
+	for _, header := range sow.keys {
+		if length := len(header) + 2; length > headerLength {
+			headerLength = length
+		}
+	}
+
+	if sow.rowCount > 0 {
+		fmt.Fprintln(sow.out)
+	}
+	cells := sow.stringRow(row, true)
+	if sow.colorEnabled() {
+		sow.styleRow(cells, row)
+	}
+	for i, header := range sow.keys {
+		value := ""
+		if i < len(cells) {
+			value = cells[i]
+		}
+		headerLabel := strings.ToUpper(header) + ":"
+		fmt.Fprintf(sow.out, "%-"+strconv.Itoa(headerLength)+"s   %s\n", headerLabel, value)
+	}
+}
+
+// writeCSVHeader writes the header record unless it was already written.
+// It returns false if the header could not be written.
+func (sow *streamingoutputwriter) writeCSVHeader() bool {
+	if sow.csvWriter != nil {
+		return true
+	}
+	sow.csvWriter = csv.NewWriter(sow.out)
+	if sow.outputFormat == TSVOutputType {
+		sow.csvWriter.Comma = tsvSeparator
+	}
+	if err := sow.csvWriter.Write(sow.keys); err != nil {
+		fmt.Fprint(sow.out, err)
+		return false
+	}
+	sow.csvWriter.Flush()
+	return true
+}
+
+func (sow *streamingoutputwriter) writeCSVRow(row []interface{}) {
+	if !sow.writeCSVHeader() {
+		return
+	}
+
+	record := make([]string, len(sow.keys))
+	copy(record, sow.stringRow(row, false))
+	if err := sow.csvWriter.Write(record); err != nil {
+		fmt.Fprint(sow.out, err)
+		return
+	}
+	sow.csvWriter.Flush()
+}
+
+// writeTemplateRow evaluates the template against each row on its own. Once
+// the template fails, the error is written and the rows are dropped.
+func (sow *streamingoutputwriter) writeTemplateRow(row []interface{}) {
+	if sow.reportTemplateError() {
+		return
+	}
+
+	generic, err := toGenericJSON(sow.rowData(row))
+	if err != nil {
+		fmt.Fprintln(sow.out, err)
+		return
+	}
+	if err := sow.tmpl.execute(sow.out, generic); err != nil {
+		sow.tmplErr = errors.Wrapf(err, "error executing %s template", sow.outputFormat)
+		sow.reportTemplateError()
+	}
+}
+
+// reportTemplateError returns true if the template failed, writing the error
+// the first time only.
+func (sow *streamingoutputwriter) reportTemplateError() bool {
+	if sow.tmplErr == nil {
+		return false
+	}
+	if !sow.tmplReported {
+		sow.tmplReported = true
+		fmt.Fprintln(sow.out, sow.tmplErr)
+	}
+	return true
+}
+
+func (sow *streamingoutputwriter) writeMarkdownHeader() {
+	if sow.headerDone {
+		return
+	}
+	sow.headerDone = true
+
+	// Render an empty table to write the header and separator lines
+	ow := &outputwriter{out: sow.out, keys: sow.keys}
+	renderMarkdown(ow)
+}
+
+func (sow *streamingoutputwriter) writeHTMLHeader() {
+	if sow.headerDone {
+		return
+	}
+	sow.headerDone = true
+	writeHTMLTableStart(sow.out, sow.keys)
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingOutputWriterJSON(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(JSONOutputType), nil, "name", "count")
+
+	sow.AddRow("one", 1)
+	require.Equal(t, "{\"count\":1,\"name\":\"one\"}\n", b.String())
+
+	sow.AddRow("two", 2)
+	sow.Render()
+	require.Equal(t, "{\"count\":1,\"name\":\"one\"}\n{\"count\":2,\"name\":\"two\"}\n", b.String())
+}
+
+func TestStreamingOutputWriterYAML(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(YAMLOutputType), nil, "name", "count")
+
+	sow.AddRow("one", 1)
+	sow.AddRow("two", 2)
+	sow.Render()
+	require.Equal(t, "---\ncount: 1\nname: one\n---\ncount: 2\nname: two\n", b.String())
+}
+
+func TestStreamingOutputWriterTable(t *testing.T) {
+	var b bytes.Buffer
+	opts := []OutputWriterOption{WithStreamingSampleSize(2)}
+	sow := NewStreamingOutputWriter(&b, string(TableOutputType), opts, "name", "count")
+
+	// Rows are held back until the column widths are known
+	sow.AddRow("one", 1)
+	require.Empty(t, b.String())
+
+	sow.AddRow("three", 3)
+	sow.AddRow("a-much-longer-name", 100)
+	sow.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 5, len(lines), "%v", lines)
+	require.Equal(t, "  NAME   COUNT  ", lines[0])
+	require.Equal(t, "  one    1      ", lines[1])
+	require.Equal(t, "  three  3      ", lines[2])
+	require.Equal(t, "  a-much-longer-name  100    ", lines[3])
+}
+
+func TestStreamingOutputWriterFlush(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(TableOutputType), nil, "name")
+
+	sow.AddRow("one")
+	require.Empty(t, b.String())
+
+	sow.Flush()
+	require.Equal(t, "  NAME  \n  one   \n", b.String())
+
+	sow.AddRow("two")
+	require.Equal(t, "  NAME  \n  one   \n  two   \n", b.String())
+}
+
+func TestStreamingOutputWriterCSV(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(CSVOutputType), nil, "name", "count")
+
+	sow.AddRow("one, two", 1)
+	require.Equal(t, "name,count\n\"one, two\",1\n", b.String())
+}
+
+func TestStreamingOutputWriterCSVWithoutRows(t *testing.T) {
+	for format, expected := range map[OutputType]string{
+		CSVOutputType: "name,count\n",
+		TSVOutputType: "name\tcount\n",
+	} {
+		var b bytes.Buffer
+		sow := NewStreamingOutputWriter(&b, string(format), nil, "name", "count")
+
+		sow.Render()
+		require.Equal(t, expected, b.String(), format)
+	}
+}
+
+func TestStreamingOutputWriterSortBy(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(JSONOutputType), []OutputWriterOption{WithSortBy(SortKey{Column: "name"})}, "name")
+
+	sow.AddRow("two")
+	sow.AddRow("one")
+	sow.Render()
+	require.Equal(t, "sorting is not supported by streaming output\n", b.String())
+}
+
+func TestStreamingOutputWriterTemplate(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, `go-template={{.name}}:{{.count}}{{"\n"}}`, nil, "name", "count")
+	sow.AddRow("one", 1)
+	sow.AddRow("two", 2)
+	sow.Render()
+	require.Equal(t, "one:1\ntwo:2\n", b.String())
+
+	// A template which cannot be parsed is reported once
+	b.Reset()
+	sow = NewStreamingOutputWriter(&b, "go-template={{.name", nil, "name", "count")
+	sow.AddRow("one", 1)
+	sow.AddRow("two", 2)
+	sow.Render()
+	require.Equal(t, 1, strings.Count(b.String(), "\n"), b.String())
+	require.Contains(t, b.String(), "unclosed action")
+
+	b.Reset()
+	sow = NewStreamingOutputWriter(&b, "jsonpath={.name", nil, "name", "count")
+	sow.Render()
+	require.Contains(t, b.String(), "invalid jsonpath template")
+
+	// The rows are dropped once the template fails
+	b.Reset()
+	sow = NewStreamingOutputWriter(&b, `go-template={{.name.first}}{{"\n"}}`, nil, "name", "count")
+	sow.AddRow("one", 1)
+	sow.AddRow("two", 2)
+	sow.AddRow("three", 3)
+	sow.Render()
+	require.Equal(t, 1, strings.Count(b.String(), "error executing go-template template"), b.String())
+}
+
+func TestStreamingOutputWriterListTable(t *testing.T) {
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(ListTableOutputType), nil, "name", "count")
+
+	sow.AddRow("one", 1)
+	sow.AddRow("two", 2)
+	sow.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 6, len(lines), "%v", lines)
+	require.Contains(t, lines[0], "NAME:")
+	require.Contains(t, lines[0], "one")
+	require.Empty(t, lines[2])
+	require.Contains(t, lines[3], "NAME:")
+	require.Contains(t, lines[3], "two")
+}
+
+type fakeSpinner struct {
+	OutputWriterSpinner
+	stopped int
+	// out is the output of the writer, and written the length of the output
+	// when the spinner was stopped
+	out     *bytes.Buffer
+	written int
+}
+
+func (f *fakeSpinner) StopSpinner() {
+	f.stopped++
+	if f.out != nil {
+		f.written = f.out.Len()
+	}
+}
+
+func TestStreamingOutputWriterSpinnerHandoff(t *testing.T) {
+	var b bytes.Buffer
+	spinner := &fakeSpinner{}
+	opts := []OutputWriterOption{WithSpinner(spinner), WithStreamingSampleSize(2)}
+	sow := NewStreamingOutputWriter(&b, string(TableOutputType), opts, "name")
+
+	sow.AddRow("one")
+	require.Equal(t, 0, spinner.stopped)
+
+	sow.AddRow("two")
+	require.Equal(t, 1, spinner.stopped)
+
+	sow.AddRow("three")
+	sow.Render()
+	require.Equal(t, 1, spinner.stopped)
+}
+
+func TestStreamingOutputWriterStopsSpinnerBeforeOutput(t *testing.T) {
+	for _, format := range []OutputType{TableOutputType, MarkdownOutputType, HTMLOutputType, JSONOutputType, CSVOutputType} {
+		var b bytes.Buffer
+		spinner := &fakeSpinner{out: &b}
+		sow := NewStreamingOutputWriter(&b, string(format), []OutputWriterOption{WithSpinner(spinner)}, "name")
+
+		// No rows are streamed
+		sow.Render()
+		require.Equal(t, 1, spinner.stopped, format)
+		require.Equal(t, 0, spinner.written, format)
+	}
+}
+
+func TestOutputWriterSpinnerHandoff(t *testing.T) {
+	var b bytes.Buffer
+	spinner := &fakeSpinner{}
+	ow := NewOutputWriterWithOptions(&b, string(JSONOutputType), []OutputWriterOption{WithSpinner(spinner)}, "name")
+
+	ow.AddRow("one")
+	require.Equal(t, 0, spinner.stopped)
+
+	ow.Render()
+	require.Equal(t, 1, spinner.stopped)
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	auroraPackage "github.com/logrusorgru/aurora"
+	"github.com/mattn/go-isatty"
+)
+
+// CellStyle is the style of a table cell, usually chosen from the semantic
+// meaning of its value.
+type CellStyle int
+
+const (
+	// CellStylePlain leaves the cell unstyled.
+	CellStylePlain CellStyle = iota
+	// CellStyleSuccess is used for successful or healthy values, shown in green.
+	CellStyleSuccess
+	// CellStyleWarning is used for pending or degraded values, shown in yellow.
+	CellStyleWarning
+	// CellStyleFailure is used for failed or unhealthy values, shown in red.
+	CellStyleFailure
+	// CellStyleInfo is used for informational values, shown in cyan.
+	CellStyleInfo
+	// CellStyleBold shows the value in bold.
+	CellStyleBold
+)
+
+// CellStyleFunc returns the style of a cell from its value.
+type CellStyleFunc func(value interface{}) CellStyle
+
+var statusStyles = map[string]CellStyle{
+	"ready":       CellStyleSuccess,
+	"running":     CellStyleSuccess,
+	"succeeded":   CellStyleSuccess,
+	"success":     CellStyleSuccess,
+	"healthy":     CellStyleSuccess,
+	"installed":   CellStyleSuccess,
+	"active":      CellStyleSuccess,
+	"true":        CellStyleSuccess,
+	"pending":     CellStyleWarning,
+	"unknown":     CellStyleWarning,
+	"updating":    CellStyleWarning,
+	"creating":    CellStyleWarning,
+	"deleting":    CellStyleWarning,
+	"terminating": CellStyleWarning,
+	"degraded":    CellStyleWarning,
+	"failed":      CellStyleFailure,
+	"failure":     CellStyleFailure,
+	"error":       CellStyleFailure,
+	"unhealthy":   CellStyleFailure,
+	"notready":    CellStyleFailure,
+	"not ready":   CellStyleFailure,
+	"false":       CellStyleFailure,
+}
+
+// StatusStyle styles common status values, such as "Ready", "Pending" or
+// "Failed", as success, warning or failure. Values are matched case
+// insensitively, other values are left plain.
+func StatusStyle(value interface{}) CellStyle {
+	if value == nil {
+		return CellStylePlain
+	}
+	return statusStyles[strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", value)))]
+}
+
+// WithColumnStyle sets the function used to style the cells of the column
+// with the given header. Styles are only applied to table and list table
+// output written to a terminal, unless color is disabled through the
+// environment, see IsTTYEnabled. All other output is plain text.
+func WithColumnStyle(header string, style CellStyleFunc) OutputWriterOption {
+	return func(ow *outputwriter) {
+		if ow.styles == nil {
+			ow.styles = map[string]CellStyleFunc{}
+		}
+		ow.styles[strings.ToLower(header)] = style
+	}
+}
+
+// WithColor forces the cell styles to be applied, or not, whatever the
+// output is attached to. The styles are never applied if colors are disabled
+// with the NO_COLOR, TANZU_CLI_NO_COLOR or TERM=dumb environment variables.
+func WithColor(enabled bool) OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.color = &enabled
+	}
+}
+
+// colorEnabled returns true if cell styles should be applied.
+func (ow *outputwriter) colorEnabled() bool {
+	if colorDisabled() {
+		return false
+	}
+	if ow.color != nil {
+		return *ow.color
+	}
+	return len(ow.styles) > 0 && isColorEnabled(ow.out)
+}
+
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// styleRows applies the column styles to the text of the rows, using the
+// values the rows were created from.
+func (ow *outputwriter) styleRows(rows [][]string, values [][]interface{}) {
+	if !ow.colorEnabled() {
+		return
+	}
+	for i, row := range rows {
+		if i < len(values) {
+			ow.styleRow(row, values[i])
+		}
+	}
+}
+
+// styleRow applies the column styles to the text of a row.
+func (ow *outputwriter) styleRow(row []string, values []interface{}) {
+	for i := 0; i < len(row) && i < len(ow.keys) && i < len(values); i++ {
+		if style := ow.styles[strings.ToLower(ow.keys[i])]; style != nil {
+			row[i] = applyCellStyle(row[i], style(values[i]))
+		}
+	}
+}
+
+// applyCellStyle styles each line of the text on its own, so that wrapped
+// cells do not bleed into the neighbouring columns.
+func applyCellStyle(text string, style CellStyle) string {
+	if style == CellStylePlain || text == "" {
+		return text
+	}
+
+	colors := auroraPackage.NewAurora(true)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		switch style {
+		case CellStyleSuccess:
+			lines[i] = colors.Green(line).String()
+		case CellStyleWarning:
+			lines[i] = colors.Yellow(line).String()
+		case CellStyleFailure:
+			lines[i] = colors.Red(line).String()
+		case CellStyleInfo:
+			lines[i] = colors.Cyan(line).String()
+		case CellStyleBold:
+			lines[i] = colors.Bold(line).String()
+		}
+	}
+	return strings.Join(lines, "\n")
+}
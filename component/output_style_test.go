@@ -0,0 +1,107 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	green = "\x1b[32m"
+	red   = "\x1b[31m"
+	reset = "\x1b[0m"
+)
+
+func TestStatusStyle(t *testing.T) {
+	require.Equal(t, CellStyleSuccess, StatusStyle("Ready"))
+	require.Equal(t, CellStyleSuccess, StatusStyle("running"))
+	require.Equal(t, CellStyleWarning, StatusStyle("Pending"))
+	require.Equal(t, CellStyleFailure, StatusStyle("FAILED"))
+	require.Equal(t, CellStyleFailure, StatusStyle(false))
+	require.Equal(t, CellStylePlain, StatusStyle("something else"))
+	require.Equal(t, CellStylePlain, StatusStyle(nil))
+}
+
+// allowColor clears the environment variables disabling colors
+func allowColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TANZU_CLI_NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+}
+
+func TestTableColumnStyle(t *testing.T) {
+	allowColor(t)
+	opts := []OutputWriterOption{WithColumnStyle("Status", StatusStyle), WithColor(true)}
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), opts, "Name", "Status")
+	tab.AddRow("one", "Ready")
+	tab.AddRow("two", "Failed")
+	tab.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 4, len(lines), "%v", lines)
+	require.Contains(t, lines[1], green+"Ready"+reset)
+	require.Contains(t, lines[2], red+"Failed"+reset)
+	require.Equal(t, len(lines[1])-len(green+reset), len(lines[0]), "%q", lines)
+
+	b.Reset()
+	tab = NewOutputWriterWithOptions(&b, string(ListTableOutputType), opts, "Name", "Status")
+	tab.AddRow("one", "Ready")
+	tab.AddRow("two", "Failed")
+	tab.Render()
+	require.Contains(t, b.String(), green+"Ready"+reset+", "+red+"Failed"+reset)
+}
+
+func TestColumnStylePlainOutput(t *testing.T) {
+	// Color is not enabled when not writing to a terminal
+	opts := []OutputWriterOption{WithColumnStyle("Status", StatusStyle)}
+
+	for _, format := range []OutputType{TableOutputType, ListTableOutputType, JSONOutputType, YAMLOutputType} {
+		var b bytes.Buffer
+		tab := NewOutputWriterWithOptions(&b, string(format), opts, "Name", "Status")
+		tab.AddRow("one", "Ready")
+		tab.Render()
+		require.NotContains(t, b.String(), "\x1b[", "%s", format)
+		require.Contains(t, b.String(), "Ready", "%s", format)
+	}
+
+	// Structured output is never styled
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(JSONOutputType), append(opts, WithColor(true)), "Name", "Status")
+	tab.AddRow("one", "Ready")
+	tab.Render()
+	require.NotContains(t, b.String(), "\x1b[")
+}
+
+func TestColorDisabledByEnvironment(t *testing.T) {
+	allowColor(t)
+	t.Setenv("NO_COLOR", "1")
+	opts := []OutputWriterOption{WithColumnStyle("Status", StatusStyle), WithColor(true)}
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), opts, "Name", "Status")
+	tab.AddRow("one", "Ready")
+	tab.Render()
+	require.NotContains(t, b.String(), "\x1b[")
+}
+
+func TestStreamingTableColumnStyle(t *testing.T) {
+	allowColor(t)
+	opts := []OutputWriterOption{WithColumnStyle("Status", StatusStyle), WithColor(true), WithStreamingSampleSize(1)}
+
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(TableOutputType), opts, "Status", "Name")
+	sow.AddRow("Ready", "one")
+	sow.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	require.Equal(t, "  STATUS  NAME  ", lines[0])
+	require.Equal(t, "  "+green+"Ready"+reset+"   one   ", lines[1])
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// parseOutputFormat splits a parameterised output format such as
+// "jsonpath={.name}" into its output type and parameter. Formats that do not
+// take a parameter are returned as is.
+func parseOutputFormat(outputFormat string) (OutputType, string) {
+	if i := strings.Index(outputFormat, "="); i >= 0 {
+		outputType := OutputType(outputFormat[:i])
+		if isTemplateOutputType(outputType) || outputType == CustomColumnsOutputType {
+			return outputType, outputFormat[i+1:]
+		}
+	}
+	return OutputType(outputFormat), ""
+}
+
+// isTemplateOutputType returns true if the output type is rendered using a
+// user supplied template.
+func isTemplateOutputType(outputType OutputType) bool {
+	switch outputType {
+	case JSONPathOutputType, GoTemplateOutputType, GoTemplateFileOutputType:
+		return true
+	}
+	return false
+}
+
+// ValidateOutputFormat returns an error if the output format is a
+// parameterised format, such as jsonpath, go-template or custom-columns,
+// whose parameter is missing or invalid. Commands can use it to validate the
+// output flag before doing any work.
+func ValidateOutputFormat(outputFormat string) error {
+	outputType, param := parseOutputFormat(outputFormat)
+	if outputType == CustomColumnsOutputType {
+		_, err := parseCustomColumns(param)
+		return err
+	}
+	if !isTemplateOutputType(outputType) {
+		return nil
+	}
+	_, err := newOutputTemplate(outputType, param)
+	return err
+}
+
+// outputTemplate is a parsed jsonpath or go-template output template.
+type outputTemplate interface {
+	execute(out io.Writer, data interface{}) error
+}
+
+type goTemplate struct {
+	tmpl *template.Template
+}
+
+func (gt *goTemplate) execute(out io.Writer, data interface{}) error {
+	return gt.tmpl.Execute(out, data)
+}
+
+// newOutputTemplate parses the template for the given output type.
+func newOutputTemplate(outputType OutputType, param string) (outputTemplate, error) {
+	if param == "" {
+		return nil, errors.Errorf("template format specified but no template given, use %s=<template>", outputType)
+	}
+
+	switch outputType {
+	case JSONPathOutputType:
+		return parseJSONPath(param)
+	case GoTemplateOutputType:
+		tmpl, err := template.New("output").Parse(param)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid go-template %q", param)
+		}
+		return &goTemplate{tmpl: tmpl}, nil
+	case GoTemplateFileOutputType:
+		content, err := os.ReadFile(param)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading go-template file %s", param)
+		}
+		tmpl, err := template.New("output").Parse(string(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid go-template in file %s", param)
+		}
+		return &goTemplate{tmpl: tmpl}, nil
+	}
+	return nil, errors.Errorf("unsupported template output format %s", outputType)
+}
+
+// renderTemplate prints the data using a jsonpath or go-template template.
+// The data is converted to its generic JSON form first, so that templates
+// refer to fields by the same names as the json output.
+func renderTemplate(out io.Writer, outputType OutputType, param string, data interface{}) {
+	tmpl, err := newOutputTemplate(outputType, param)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+
+	generic, err := toGenericJSON(data)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+
+	if err := tmpl.execute(out, generic); err != nil {
+		fmt.Fprintln(out, errors.Wrapf(err, "error executing %s template", outputType))
+	}
+}
+
+// toGenericJSON converts data to the maps, slices and scalars obtained by
+// round tripping it through JSON. Numbers are decoded as json.Number, so that
+// large integers keep their precision and are printed as they are.
+func toGenericJSON(data interface{}) (interface{}, error) {
+	bytesJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(bytesJSON))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
@@ -5,8 +5,11 @@ package component
 
 import (
 	"bytes"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -317,3 +320,482 @@ type testStruct struct {
 	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
 	Namespace string `json:"spacename,omitempty" yaml:"spacename,omitempty"`
 }
+
+func TestNewOutputWriterCSV(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriter(&b, string(CSVOutputType), "name", "description")
+	require.NotNil(t, tab)
+	tab.AddRow("plain", "value")
+	tab.AddRow("comma", "one, two")
+	tab.AddRow("quote", "say \"hi\"")
+	tab.AddRow("newline", "first\nsecond")
+	tab.Render()
+
+	expected := "name,description\n" +
+		"plain,value\n" +
+		"comma,\"one, two\"\n" +
+		"quote,\"say \"\"hi\"\"\"\n" +
+		"newline,\"first\nsecond\"\n"
+	require.Equal(t, expected, b.String())
+}
+
+func TestNewOutputWriterTSV(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriter(&b, string(TSVOutputType), "a", "b", "c")
+	require.NotNil(t, tab)
+	tab.AddRow("1", "2", "3")
+	tab.AddRow("4", "tab\there")
+	tab.Render()
+
+	expected := "a\tb\tc\n" +
+		"1\t2\t3\n" +
+		"4\t\"tab\there\"\t\n"
+	require.Equal(t, expected, b.String())
+}
+
+func TestOutputWriterNilValues(t *testing.T) {
+	for format, expected := range map[OutputType]string{
+		TableOutputType:     "  A  B      \n  1  <nil>  \n",
+		ListTableOutputType: "A:           1\nB:           <nil>\n",
+		CSVOutputType:       "a,b\n1,<nil>\n",
+	} {
+		var b bytes.Buffer
+		tab := NewOutputWriter(&b, string(format), "a", "b")
+		tab.AddRow("1", nil)
+		tab.Render()
+		require.Equal(t, expected, b.String(), format)
+	}
+}
+
+func TestObjectWriterCSV(t *testing.T) {
+	type spec struct {
+		Version string   `json:"version"`
+		Tags    []string `json:"tags"`
+	}
+	type item struct {
+		testStruct `json:",inline"`
+		Spec       spec              `json:"spec"`
+		Labels     map[string]string `json:"labels"`
+	}
+
+	data := []item{
+		{
+			testStruct: testStruct{Name: "hal", Namespace: "Jupiter"},
+			Spec:       spec{Version: "v1", Tags: []string{"a", "b"}},
+			Labels:     map[string]string{"team": "x"},
+		},
+		{
+			testStruct: testStruct{Name: "eve", Namespace: "Earth, Moon"},
+			Spec:       spec{Version: "v2"},
+		},
+	}
+
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, string(CSVOutputType), data)
+	out.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 4, len(lines), "%v", lines)
+	require.Equal(t, "name,spacename,spec.version,spec.tags,labels.team", lines[0])
+	require.Equal(t, `hal,Jupiter,v1,"[""a"",""b""]",x`, lines[1])
+	require.Equal(t, `eve,"Earth, Moon",v2,null,`, lines[2])
+}
+
+type csvMeta struct {
+	Tags   []string          `json:"tags"`
+	Labels map[string]string `json:"labels"`
+}
+
+func TestObjectWriterCSVUnexportedFields(t *testing.T) {
+	type item struct {
+		csvMeta `json:",inline"`
+		Name    string            `json:"name"`
+		hidden  []string          //nolint:unused
+		labels  map[string]string //nolint:unused
+	}
+
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, string(CSVOutputType), []item{
+		{csvMeta: csvMeta{Tags: []string{"a", "b"}, Labels: map[string]string{"team": "x"}}, Name: "hal", hidden: []string{"c"}},
+	})
+	require.NotPanics(t, out.Render)
+	require.Equal(t, "tags,labels.team,name\n\"[\"\"a\"\",\"\"b\"\"]\",x,hal\n", b.String())
+
+	// Values reached through unexported fields are written without being marshaled
+	hidden := reflect.ValueOf(item{hidden: []string{"c"}, labels: map[string]string{"team": "x"}})
+	row := map[string]string{}
+	var keys []string
+	require.NotPanics(t, func() {
+		require.NoError(t, flattenValue("hidden", hidden.Field(2), row, &keys, map[string]bool{}))
+		require.NoError(t, flattenValue("labels", hidden.Field(3), row, &keys, map[string]bool{}))
+	})
+	require.Equal(t, map[string]string{"hidden": "[c]", "labels.team": "x"}, row)
+}
+
+func TestObjectWriterTSVSingleObject(t *testing.T) {
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, string(TSVOutputType), &testStruct{Name: "hal", Namespace: "Jupiter"})
+	out.Render()
+
+	require.Equal(t, "name\tspacename\nhal\tJupiter\n", b.String())
+}
+
+func TestNewOutputWriterJSONPath(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriter(&b, `jsonpath={range [*]}{.name}:{.a_key}{"\n"}{end}`, "name", "a key")
+	require.NotNil(t, tab)
+	tab.AddRow("one", "1")
+	tab.AddRow("two", "2")
+	tab.Render()
+
+	require.Equal(t, "one:1\ntwo:2\n", b.String())
+}
+
+func TestNewOutputWriterGoTemplate(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriter(&b, `go-template={{range .}}{{.name}} {{end}}`, "name")
+	require.NotNil(t, tab)
+	tab.AddRow("one")
+	tab.AddRow("two")
+	tab.Render()
+
+	require.Equal(t, "one two ", b.String())
+}
+
+func TestObjectWriterJSONPath(t *testing.T) {
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, "jsonpath={.name}/{.spacename}", &testStruct{Name: "hal", Namespace: "Jupiter"})
+	out.Render()
+
+	require.Equal(t, "hal/Jupiter", b.String())
+}
+
+func TestObjectWriterGoTemplateFile(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "template")
+	require.NoError(t, err)
+	_, err = f.WriteString("{{.name}} lives on {{.spacename}}\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, "go-template-file="+f.Name(), &testStruct{Name: "hal", Namespace: "Jupiter"})
+	out.Render()
+
+	require.Equal(t, "hal lives on Jupiter\n", b.String())
+}
+
+func TestObjectWriterInvalidTemplate(t *testing.T) {
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, "go-template={{.name", &testStruct{Name: "hal"})
+	out.Render()
+
+	require.Contains(t, b.String(), "invalid go-template")
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	require.NoError(t, ValidateOutputFormat(string(TableOutputType)))
+	require.NoError(t, ValidateOutputFormat("jsonpath={.name}"))
+	require.NoError(t, ValidateOutputFormat("go-template={{.name}}"))
+
+	err := ValidateOutputFormat("jsonpath=")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no template given")
+
+	err = ValidateOutputFormat("jsonpath={.name")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid jsonpath template")
+
+	err = ValidateOutputFormat("go-template-file=/does/not/exist")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error reading go-template file")
+}
+
+type testPlanet struct {
+	Name   string            `json:"name"`
+	Moons  []string          `json:"moons,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (p testPlanet) TableColumns() []ObjectColumn {
+	return []ObjectColumn{
+		{Header: "Name", Path: ".name"},
+		{Header: "Moons", Path: ".moons[*]"},
+	}
+}
+
+func TestObjectWriterCustomColumns(t *testing.T) {
+	planets := []testPlanet{
+		{Name: "earth", Moons: []string{"moon"}, Labels: map[string]string{"zone": "habitable"}},
+		{Name: "mars", Moons: []string{"phobos", "deimos"}},
+	}
+
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, "custom-columns=PLANET:.name,ZONE:.labels.zone", planets)
+	out.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 4, len(lines), "%v", lines)
+	require.Contains(t, lines[0], "PLANET")
+	require.Contains(t, lines[0], "ZONE")
+	require.Contains(t, lines[1], "earth")
+	require.Contains(t, lines[1], "habitable")
+	require.Contains(t, lines[2], "mars")
+	require.Contains(t, lines[2], "<none>")
+}
+
+func TestObjectWriterDefaultTableColumns(t *testing.T) {
+	planets := []*testPlanet{
+		{Name: "earth", Moons: []string{"moon"}},
+		{Name: "mars", Moons: []string{"phobos", "deimos"}},
+	}
+
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, string(TableOutputType), planets)
+	out.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 4, len(lines), "%v", lines)
+	require.Contains(t, lines[0], "NAME")
+	require.Contains(t, lines[0], "MOONS")
+	require.Contains(t, lines[1], "earth")
+	require.Contains(t, lines[2], "phobos,deimos")
+
+	b.Reset()
+	out = NewObjectWriter(&b, string(ListTableOutputType), planets[1])
+	out.Render()
+
+	lines = strings.Split(b.String(), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	require.Contains(t, lines[0], "NAME:")
+	require.Contains(t, lines[0], "mars")
+	require.Contains(t, lines[1], "MOONS:")
+	require.Contains(t, lines[1], "phobos,deimos")
+}
+
+func TestObjectWriterTableWithoutColumns(t *testing.T) {
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, string(TableOutputType), &testStruct{Name: "hal", Namespace: "Jupiter"})
+	out.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	require.Contains(t, lines[0], "NAME")
+	require.Contains(t, lines[0], "SPACENAME")
+	require.Contains(t, lines[1], "hal")
+	require.Contains(t, lines[1], "Jupiter")
+}
+
+func TestObjectWriterInvalidCustomColumns(t *testing.T) {
+	var b bytes.Buffer
+	out := NewObjectWriter(&b, "custom-columns=NAME", &testStruct{Name: "hal"})
+	out.Render()
+	require.Contains(t, b.String(), "invalid custom-columns specification")
+
+	require.Error(t, ValidateOutputFormat("custom-columns="))
+	require.Error(t, ValidateOutputFormat("custom-columns=NAME:.name{"))
+	require.NoError(t, ValidateOutputFormat("custom-columns=NAME:.name,NS:.spacename"))
+}
+
+func TestOutputWriterTypedValues(t *testing.T) {
+	var b bytes.Buffer
+	tab := NewOutputWriter(&b, string(JSONOutputType), "name", "count", "ready", "tags", "labels")
+	require.NotNil(t, tab)
+	tab.AddRow("hal", 3, true, []string{"a", "b"}, map[string]string{"team": "x"})
+	tab.Render()
+
+	require.JSONEq(t, `[{"name":"hal","count":3,"ready":true,"tags":["a","b"],"labels":{"team":"x"}}]`, b.String())
+
+	b.Reset()
+	tab = NewOutputWriter(&b, string(YAMLOutputType), "count", "ready")
+	tab.AddRow(3, false)
+	tab.Render()
+
+	require.Equal(t, "- count: 3\n  ready: false\n", b.String())
+
+	b.Reset()
+	tab = NewOutputWriter(&b, string(TableOutputType), "count", "ready")
+	tab.AddRow(3, false)
+	tab.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	require.Equal(t, "  3      false  ", lines[1])
+}
+
+func TestOutputWriterColumnFormatter(t *testing.T) {
+	opts := []OutputWriterOption{
+		WithColumnFormatter("Size", ByteSizeFormatter),
+		WithColumnFormatter("age", DurationFormatter),
+	}
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), opts, "Name", "Size", "Age")
+	tab.AddRow("hal", 1536, 90*time.Second)
+	tab.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	require.Contains(t, lines[1], "1.5 KiB")
+	require.Contains(t, lines[1], "1m30s")
+
+	b.Reset()
+	tab = NewOutputWriterWithOptions(&b, string(ListTableOutputType), opts, "Name", "Size", "Age")
+	tab.AddRow("hal", 1536, 90*time.Second)
+	tab.Render()
+
+	require.Contains(t, b.String(), "1.5 KiB")
+	require.Contains(t, b.String(), "1m30s")
+
+	// Structured output keeps the original value
+	b.Reset()
+	tab = NewOutputWriterWithOptions(&b, string(JSONOutputType), opts, "Name", "Size", "Age")
+	tab.AddRow("hal", 1536, 90*time.Second)
+	tab.Render()
+
+	require.JSONEq(t, `[{"name":"hal","size":1536,"age":90000000000}]`, b.String())
+}
+
+func TestOutputWriterSortBy(t *testing.T) {
+	var b bytes.Buffer
+	opts := []OutputWriterOption{WithSortBy(SortKey{Column: "Status"}, SortKey{Column: "Count", Descending: true})}
+	tab := NewOutputWriterWithOptions(&b, string(JSONOutputType), opts, "Name", "Status", "Count")
+	tab.AddRow("a", "Ready", 2)
+	tab.AddRow("b", "Failed", 1)
+	tab.AddRow("c", "Ready", 10)
+	tab.AddRow("d", "Failed", 3)
+	tab.Render()
+
+	require.JSONEq(t, `[
+		{"name":"d","status":"Failed","count":3},
+		{"name":"b","status":"Failed","count":1},
+		{"name":"c","status":"Ready","count":10},
+		{"name":"a","status":"Ready","count":2}
+	]`, b.String())
+}
+
+func TestOutputWriterSortByNumericStrings(t *testing.T) {
+	keys, err := ParseSortBy("-size:num")
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(ListTableOutputType), []OutputWriterOption{WithSortBy(keys...)}, "Name", "Size")
+	tab.AddRow("a", "9")
+	tab.AddRow("b", "10")
+	tab.AddRow("c", "100")
+	tab.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Contains(t, lines[0], "c, b, a")
+	require.Contains(t, lines[1], "100, 10, 9")
+}
+
+func TestOutputWriterFilters(t *testing.T) {
+	filters, err := ParseFilters("status=Ready,count>1")
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(YAMLOutputType), []OutputWriterOption{WithFilters(filters...)}, "Name", "Status", "Count")
+	tab.AddRow("a", "Ready", 2)
+	tab.AddRow("b", "Failed", 5)
+	tab.AddRow("c", "Ready", 1)
+	tab.AddRow("d", "Ready", "10")
+	tab.Render()
+
+	require.Equal(t, "- count: 2\n  name: a\n  status: Ready\n- count: \"10\"\n  name: d\n  status: Ready\n", b.String())
+}
+
+func TestOutputWriterFiltersNumeric(t *testing.T) {
+	filters, err := ParseFilters("version=1.1,size:num>9")
+	require.NoError(t, err)
+	require.Equal(t, []ColumnFilter{
+		{Column: "version", Operator: FilterEquals, Value: "1.1"},
+		{Column: "size", Operator: FilterGreaterThan, Value: "9", Numeric: true},
+	}, filters)
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(CSVOutputType), []OutputWriterOption{WithFilters(filters...)}, "Name", "Version", "Size")
+	tab.AddRow("a", "1.1", "10")
+	tab.AddRow("b", "1.10", "10")
+	tab.AddRow("c", "1.1", "9")
+	tab.AddRow("d", "1.1", 12)
+	tab.Render()
+
+	// Strings are compared as numbers only with :num
+	require.Equal(t, "Name,Version,Size\na,1.1,10\nd,1.1,12\n", b.String())
+}
+
+func TestOutputWriterColumns(t *testing.T) {
+	opts := []OutputWriterOption{
+		WithColumns("count", "NAME"),
+		WithFilters(ColumnFilter{Column: "status", Operator: FilterNotEquals, Value: "Failed"}),
+		WithSortBy(SortKey{Column: "name", Descending: true}),
+	}
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), opts, "Name", "Status", "Count")
+	tab.AddRow("a", "Ready", 2)
+	tab.AddRow("b", "Failed", 5)
+	tab.AddRow("c", "Ready", 1)
+	tab.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 4, len(lines), "%v", lines)
+	require.NotContains(t, lines[0], "STATUS")
+	require.Regexp(t, `^\s*COUNT\s+NAME\s*$`, lines[0])
+	require.Regexp(t, `^\s*1\s+c\s*$`, lines[1])
+	require.Regexp(t, `^\s*2\s+a\s*$`, lines[2])
+
+	// Sorting is not supported when streaming
+	b.Reset()
+	sow := NewStreamingOutputWriter(&b, string(CSVOutputType), opts[:2], "Name", "Status", "Count")
+	sow.AddRow("a", "Ready", 2)
+	sow.AddRow("b", "Failed", 5)
+	sow.Render()
+
+	require.Equal(t, "Count,Name\n2,a\n", b.String())
+}
+
+func TestOutputWriterUnknownColumns(t *testing.T) {
+	for _, opt := range []OutputWriterOption{
+		WithFilters(ColumnFilter{Column: "stats", Operator: FilterEquals, Value: "Ready"}),
+		WithFilters(ColumnFilter{Column: "stats", Operator: FilterNotEquals, Value: "Ready"}),
+		WithSortBy(SortKey{Column: "stats"}),
+		WithColumns("name", "stats"),
+	} {
+		var b bytes.Buffer
+		tab := NewOutputWriterWithOptions(&b, string(TableOutputType), []OutputWriterOption{opt}, "Name", "Status")
+		tab.AddRow("a", "Ready")
+		tab.Render()
+		require.Regexp(t, `^unknown column "stats" in .*, the columns are: Name, Status\n$`, b.String())
+
+		b.Reset()
+		sow := NewStreamingOutputWriter(&b, string(CSVOutputType), []OutputWriterOption{opt}, "Name", "Status")
+		sow.AddRow("a", "Ready")
+		sow.AddRow("b", "Ready")
+		sow.Render()
+		require.Regexp(t, `^unknown column "stats" in .*, the columns are: Name, Status\n$`, b.String())
+	}
+}
+
+func TestParseFiltersInvalid(t *testing.T) {
+	_, err := ParseFilters("status")
+	require.Error(t, err)
+
+	_, err = ParseSortBy("-")
+	require.Error(t, err)
+
+	filters, err := ParseFilters("a==b, c!=d")
+	require.NoError(t, err)
+	require.Equal(t, []ColumnFilter{{Column: "a", Operator: FilterEquals, Value: "b"}, {Column: "c", Operator: FilterNotEquals, Value: "d"}}, filters)
+
+	filters, err = ParseFilters("count>=3,count<=5,name=a>b")
+	require.NoError(t, err)
+	require.Equal(t, []ColumnFilter{
+		{Column: "count", Operator: FilterGreaterOrEqual, Value: "3"},
+		{Column: "count", Operator: FilterLessOrEqual, Value: "5"},
+		{Column: "name", Operator: FilterEquals, Value: "a>b"},
+	}, filters)
+
+	_, err = ParseFilters("status!Ready")
+	require.Error(t, err)
+}
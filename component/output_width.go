@@ -0,0 +1,234 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+const (
+	ellipsis = "…"
+
+	// minColumnWidth is the narrowest a column is shrunk to, unless its
+	// header is narrower.
+	minColumnWidth = 5
+
+	// tableCellPadding is the number of characters added around each cell
+	// of a table.
+	tableCellPadding = 2
+)
+
+// ColumnWidthPolicy defines how a table column is shrunk when the table is
+// wider than the terminal.
+type ColumnWidthPolicy int
+
+const (
+	// ColumnWidthAuto wraps the column text when the table has to shrink.
+	// Columns of streamed tables are never shrunk with this policy.
+	ColumnWidthAuto ColumnWidthPolicy = iota
+	// ColumnTruncate truncates the column text, ending it with an ellipsis.
+	ColumnTruncate
+	// ColumnWrap wraps the column text over multiple lines.
+	ColumnWrap
+	// ColumnNoShrink never shrinks the column.
+	ColumnNoShrink
+)
+
+// WithColumnWidthPolicy sets how the column with the given header is shrunk
+// when the table is wider than the terminal.
+func WithColumnWidthPolicy(header string, policy ColumnWidthPolicy) OutputWriterOption {
+	return func(ow *outputwriter) {
+		if ow.widthPolicies == nil {
+			ow.widthPolicies = map[string]ColumnWidthPolicy{}
+		}
+		ow.widthPolicies[strings.ToLower(header)] = policy
+	}
+}
+
+// WithMaxWidth sets the width tables are fitted to, instead of the width of
+// the terminal. Table output is only fitted when writing to a terminal or
+// when a maximum width is set, so that output which is not attached to a
+// terminal stays the same whatever the environment.
+func WithMaxWidth(width int) OutputWriterOption {
+	return func(ow *outputwriter) {
+		ow.maxWidth = width
+	}
+}
+
+// tableWidth returns the width tables should be fitted to, or 0 if they
+// should not be fitted.
+func (ow *outputwriter) tableWidth() int {
+	if ow.maxWidth > 0 {
+		return ow.maxWidth
+	}
+	return terminalWidth(ow.out)
+}
+
+// terminalWidth returns the width of the terminal out is attached to, or 0
+// if it is not attached to a terminal.
+func terminalWidth(out io.Writer) int {
+	if !isTerminal(out) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(out.(*os.File).Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+func (ow *outputwriter) widthPolicy(header string) ColumnWidthPolicy {
+	return ow.widthPolicies[strings.ToLower(header)]
+}
+
+// fitColumnWidths returns the column widths to use so that a table with the
+// given natural widths fits in maxWidth. The widest shrinkable column is
+// narrowed first. The result may still be wider than maxWidth if the
+// columns can not shrink any further.
+func (ow *outputwriter) fitColumnWidths(widths []int, maxWidth int, shrinkable func(ColumnWidthPolicy) bool) []int {
+	fitted := make([]int, len(widths))
+	copy(fitted, widths)
+
+	minWidths := make([]int, len(widths))
+	for i := range widths {
+		minWidths[i] = widths[i]
+		if i < len(ow.keys) && shrinkable(ow.widthPolicy(ow.keys[i])) {
+			minWidths[i] = minColumnWidth
+			if w := runewidth.StringWidth(ow.keys[i]); w > minWidths[i] {
+				minWidths[i] = w
+			}
+		}
+	}
+
+	total := tableCellPadding
+	for _, w := range fitted {
+		total += w + tableCellPadding
+	}
+
+	for total > maxWidth {
+		widest := -1
+		for i, w := range fitted {
+			if w > minWidths[i] && (widest < 0 || w > fitted[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		fitted[widest]--
+		total--
+	}
+	return fitted
+}
+
+// fitTableRows shrinks the cells of the rows to fit the table width, using
+// the width policy of each column. It returns false if the table is not
+// fitted to a width.
+func (ow *outputwriter) fitTableRows(rows [][]string) bool {
+	maxWidth := ow.tableWidth()
+	if maxWidth <= 0 {
+		return false
+	}
+
+	widths := make([]int, len(ow.keys))
+	for i, key := range ow.keys {
+		widths[i] = runewidth.StringWidth(key)
+	}
+	for _, row := range rows {
+		for i := 0; i < len(row) && i < len(widths); i++ {
+			if w := maxLineWidth(row[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	fitted := ow.fitColumnWidths(widths, maxWidth, func(policy ColumnWidthPolicy) bool {
+		return policy != ColumnNoShrink
+	})
+	for i := range widths {
+		if fitted[i] == widths[i] {
+			continue
+		}
+		policy := ow.widthPolicy(ow.keys[i])
+		for _, row := range rows {
+			if i < len(row) {
+				row[i] = strings.Join(fitCell(row[i], fitted[i], policy), "\n")
+			}
+		}
+	}
+	return true
+}
+
+// fitCell returns the lines of a cell fitted to width using the policy.
+// Cells are wrapped unless the policy is to truncate them or to never
+// shrink them.
+func fitCell(cell string, width int, policy ColumnWidthPolicy) []string {
+	if maxLineWidth(cell) <= width {
+		return strings.Split(cell, "\n")
+	}
+
+	switch policy {
+	case ColumnNoShrink:
+		return strings.Split(cell, "\n")
+	case ColumnTruncate:
+		line := strings.SplitN(cell, "\n", 2)[0]
+		return []string{runewidth.Truncate(line, width, ellipsis)}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(cell, "\n") {
+		lines = append(lines, wrapLine(line, width)...)
+	}
+	return lines
+}
+
+// wrapLine wraps a line at word boundaries, breaking words that are longer
+// than width.
+func wrapLine(line string, width int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		for runewidth.StringWidth(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			head := runewidth.Truncate(word, width, "")
+			if head == "" {
+				// Always make progress, even if a character is wider than width
+				head = string([]rune(word)[:1])
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		switch {
+		case current == "":
+			current = word
+		case runewidth.StringWidth(current)+1+runewidth.StringWidth(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func maxLineWidth(s string) int {
+	width := 0
+	for _, line := range strings.Split(s, "\n") {
+		if w := runewidth.StringWidth(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableFitsMaxWidth(t *testing.T) {
+	description := "a long description that does not fit in a narrow terminal"
+	opts := []OutputWriterOption{
+		WithMaxWidth(40),
+		WithColumnWidthPolicy("Description", ColumnWrap),
+		WithColumnWidthPolicy("Name", ColumnNoShrink),
+	}
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), opts, "Name", "Description")
+	tab.AddRow("a-name-that-never-shrinks", description)
+	tab.Render()
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	require.Greater(t, len(lines), 2, "%v", lines)
+	for _, line := range lines {
+		require.LessOrEqual(t, runewidth.StringWidth(strings.TrimRight(line, " ")), 40, "%v", lines)
+	}
+	require.Contains(t, lines[1], "a-name-that-never-shrinks")
+
+	// All the words of the wrapped column are kept
+	var words []string
+	for _, line := range lines[1:] {
+		words = append(words, strings.Fields(strings.Replace(line, "a-name-that-never-shrinks", "", 1))...)
+	}
+	require.Equal(t, description, strings.Join(words, " "))
+}
+
+func TestTableTruncatesColumn(t *testing.T) {
+	opts := []OutputWriterOption{
+		WithMaxWidth(30),
+		WithColumnWidthPolicy("Message", ColumnTruncate),
+	}
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), opts, "Name", "Message")
+	tab.AddRow("one", "this message is much too long for the table")
+	tab.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	require.Contains(t, lines[1], "…")
+	require.LessOrEqual(t, runewidth.StringWidth(strings.TrimRight(lines[1], " ")), 30)
+}
+
+func TestTableNotWrappedWhenItFits(t *testing.T) {
+	message := strings.TrimSpace(strings.Repeat("a message wider than the default column width ", 3))
+
+	var b bytes.Buffer
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), []OutputWriterOption{WithMaxWidth(300)}, "Name", "Message")
+	tab.AddRow("one", message)
+	tab.Render()
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	require.Equal(t, 2, len(lines), "%v", lines)
+	require.Contains(t, lines[1], message)
+}
+
+func TestTableNotFittedWithoutTerminal(t *testing.T) {
+	long := strings.Repeat("x", 70)
+
+	var b bytes.Buffer
+	opts := []OutputWriterOption{WithColumnWidthPolicy("a", ColumnTruncate)}
+	tab := NewOutputWriterWithOptions(&b, string(TableOutputType), opts, "a", "b")
+	tab.AddRow(long, long)
+	tab.Render()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	require.Equal(t, 2, strings.Count(lines[1], long))
+}
+
+func TestStreamingTableFitsMaxWidth(t *testing.T) {
+	opts := []OutputWriterOption{
+		WithMaxWidth(20),
+		WithStreamingSampleSize(1),
+		WithColumnWidthPolicy("message", ColumnTruncate),
+	}
+
+	var b bytes.Buffer
+	sow := NewStreamingOutputWriter(&b, string(TableOutputType), opts, "name", "message")
+	sow.AddRow("one", "a message that is too long")
+	sow.AddRow("two", "another message that is too long")
+	sow.Render()
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	require.Equal(t, 3, len(lines), "%v", lines)
+	for _, line := range lines {
+		require.Equal(t, 20, runewidth.StringWidth(line), "%q", line)
+	}
+	require.True(t, strings.HasSuffix(strings.TrimRight(lines[2], " "), "…"), "%q", lines[2])
+}
+
+func TestWrapLine(t *testing.T) {
+	require.Equal(t, []string{"one two", "three"}, wrapLine("one two three", 7))
+	require.Equal(t, []string{"abcde", "fgh", "ij"}, wrapLine("abcdefgh ij", 5))
+	require.Equal(t, []string{""}, wrapLine("", 5))
+}
@@ -0,0 +1,326 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briandowns/spinner"
+	auroraPackage "github.com/logrusorgru/aurora"
+)
+
+const (
+	progressRefreshInterval = 100 * time.Millisecond
+	progressBarWidth        = 20
+	progressLogStep         = 10
+)
+
+// StepStatus is the status of a step tracked by a ProgressTracker.
+type StepStatus int
+
+const (
+	// StepPending is the status of a step that has not started yet.
+	StepPending StepStatus = iota
+	// StepRunning is the status of a step that is in progress.
+	StepRunning
+	// StepSucceeded is the status of a step that completed successfully.
+	StepSucceeded
+	// StepFailed is the status of a step that failed.
+	StepFailed
+)
+
+// String returns the name of the status.
+func (s StepStatus) String() string {
+	switch s {
+	case StepRunning:
+		return "running"
+	case StepSucceeded:
+		return "succeeded"
+	case StepFailed:
+		return "failed"
+	}
+	return "pending"
+}
+
+// ProgressTracker shows the progress of a multi-step operation, such as
+// cluster creation. When writing to a terminal it shows the list of steps
+// with their status and elapsed time, updated in place. Otherwise every
+// change is written as a timestamped log line. All methods are safe to call
+// from several goroutines.
+type ProgressTracker interface {
+	// AddStep adds a pending step.
+	AddStep(name string)
+	// StartStep marks a step as running, adding it if needed.
+	StartStep(name string)
+	// SetStepProgress sets the determinate progress of a running step,
+	// shown as a progress bar.
+	SetStepProgress(name string, current, total int64)
+	// SucceedStep marks a step as succeeded.
+	SucceedStep(name string)
+	// FailStep marks a step as failed with the given error.
+	FailStep(name string, err error)
+	// Stop writes the final state of the steps and stops updating them.
+	Stop()
+}
+
+type progressStep struct {
+	name     string
+	status   StepStatus
+	started  time.Time
+	finished time.Time
+	current  int64
+	total    int64
+	logged   int64
+	err      error
+}
+
+// progressTracker is our internal implementation.
+type progressTracker struct {
+	mutex       sync.Mutex
+	out         io.Writer
+	steps       []*progressStep
+	interactive bool
+	colors      auroraPackage.Aurora
+	linesDrawn  int
+	frame       int
+	now         func() time.Time
+	ticker      *time.Ticker
+	done        chan struct{}
+	stopped     bool
+}
+
+// NewProgressTracker returns a ProgressTracker writing to output, with the
+// given steps pending.
+func NewProgressTracker(output io.Writer, steps ...string) ProgressTracker {
+	pt := &progressTracker{
+		out:         output,
+		interactive: isTerminal(output),
+		colors:      auroraPackage.NewAurora(isColorEnabled(output)),
+		now:         time.Now,
+	}
+	for _, name := range steps {
+		pt.AddStep(name)
+	}
+	return pt
+}
+
+// AddStep adds a pending step.
+func (pt *progressTracker) AddStep(name string) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	pt.step(name)
+	pt.redraw()
+}
+
+// StartStep marks a step as running, adding it if needed.
+func (pt *progressTracker) StartStep(name string) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	step := pt.step(name)
+	step.status = StepRunning
+	step.started = pt.now()
+	// A restarted step runs again from the start
+	step.finished = time.Time{}
+	step.err = nil
+	step.current, step.total, step.logged = 0, 0, 0
+	pt.log(step, step.started, step.status.String())
+	pt.startRefresh()
+	pt.redraw()
+}
+
+// SetStepProgress sets the determinate progress of a running step.
+func (pt *progressTracker) SetStepProgress(name string, current, total int64) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	step := pt.step(name)
+	step.current, step.total = current, total
+
+	// Only log every progressLogStep percent, to keep the log readable
+	if percent := step.percent(); percent/progressLogStep > step.logged/progressLogStep {
+		step.logged = percent
+		pt.log(step, pt.now(), fmt.Sprintf("%d%% (%d/%d)", percent, current, total))
+	}
+	pt.redraw()
+}
+
+// SucceedStep marks a step as succeeded.
+func (pt *progressTracker) SucceedStep(name string) {
+	pt.finish(name, StepSucceeded, nil)
+}
+
+// FailStep marks a step as failed with the given error.
+func (pt *progressTracker) FailStep(name string, err error) {
+	pt.finish(name, StepFailed, err)
+}
+
+// Stop writes the final state of the steps and stops updating them.
+func (pt *progressTracker) Stop() {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if pt.stopped {
+		return
+	}
+	if pt.ticker != nil {
+		pt.ticker.Stop()
+		close(pt.done)
+	}
+	pt.redraw()
+	pt.stopped = true
+}
+
+func (pt *progressTracker) finish(name string, status StepStatus, err error) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	step := pt.step(name)
+	step.status = status
+	step.err = err
+	step.finished = pt.now()
+	if step.started.IsZero() {
+		step.started = step.finished
+	}
+
+	message := fmt.Sprintf("%s (%s)", status, humanDuration(step.elapsed(step.finished)))
+	if err != nil {
+		message += ": " + err.Error()
+	}
+	pt.log(step, step.finished, message)
+	pt.redraw()
+}
+
+// step returns the step with the given name, adding it if needed.
+func (pt *progressTracker) step(name string) *progressStep {
+	for _, step := range pt.steps {
+		if step.name == name {
+			return step
+		}
+	}
+	step := &progressStep{name: name}
+	pt.steps = append(pt.steps, step)
+	return step
+}
+
+// startRefresh starts updating the elapsed times and spinners of running
+// steps in the background.
+func (pt *progressTracker) startRefresh() {
+	if !pt.interactive || pt.ticker != nil || pt.stopped {
+		return
+	}
+	pt.ticker = time.NewTicker(progressRefreshInterval)
+	pt.done = make(chan struct{})
+	go func(ticker *time.Ticker, done chan struct{}) {
+		for {
+			select {
+			case <-ticker.C:
+				pt.mutex.Lock()
+				if !pt.stopped {
+					pt.frame++
+					pt.redraw()
+				}
+				pt.mutex.Unlock()
+			case <-done:
+				return
+			}
+		}
+	}(pt.ticker, pt.done)
+}
+
+// log writes a timestamped line for a change of a step, when not writing to
+// a terminal.
+func (pt *progressTracker) log(step *progressStep, at time.Time, message string) {
+	if pt.interactive || pt.stopped {
+		return
+	}
+	fmt.Fprintf(pt.out, "%s %s: %s\n", at.UTC().Format(time.RFC3339), step.name, message)
+}
+
+// redraw writes the list of steps over the previously written one, when
+// writing to a terminal and not stopped.
+func (pt *progressTracker) redraw() {
+	if !pt.interactive || pt.stopped {
+		return
+	}
+
+	var b strings.Builder
+	if pt.linesDrawn > 0 {
+		// Move the cursor back to the first line of the list
+		fmt.Fprintf(&b, "\x1b[%dA", pt.linesDrawn)
+	}
+	for _, step := range pt.steps {
+		b.WriteString("\r\x1b[2K")
+		b.WriteString(pt.stepLine(step))
+		b.WriteString("\n")
+	}
+	pt.linesDrawn = len(pt.steps)
+	fmt.Fprint(pt.out, b.String())
+}
+
+func (pt *progressTracker) stepLine(step *progressStep) string {
+	now := pt.now()
+	switch step.status {
+	case StepRunning:
+		frames := spinner.CharSets[9]
+		line := fmt.Sprintf("%s %s (%s)", pt.colors.Cyan(frames[pt.frame%len(frames)]), step.name, humanDuration(step.elapsed(now)))
+		if step.total > 0 {
+			line += " " + progressBar(step.current, step.total)
+		}
+		return line
+	case StepSucceeded:
+		return fmt.Sprintf("%s %s (%s)", pt.colors.Green("✓"), step.name, humanDuration(step.elapsed(now)))
+	case StepFailed:
+		line := fmt.Sprintf("%s %s (%s)", pt.colors.Red("✗"), step.name, humanDuration(step.elapsed(now)))
+		if step.err != nil {
+			line += ": " + step.err.Error()
+		}
+		return line
+	}
+	return fmt.Sprintf("%s %s", pt.colors.Faint("-"), step.name)
+}
+
+// elapsed returns the time the step has been running for, or ran for once
+// finished.
+func (s *progressStep) elapsed(now time.Time) time.Duration {
+	if s.started.IsZero() {
+		return 0
+	}
+	if !s.finished.IsZero() {
+		return s.finished.Sub(s.started)
+	}
+	return now.Sub(s.started)
+}
+
+func (s *progressStep) percent() int64 {
+	if s.total <= 0 {
+		return 0
+	}
+	percent := s.current * 100 / s.total
+	switch {
+	case percent < 0:
+		return 0
+	case percent > 100:
+		return 100
+	}
+	return percent
+}
+
+// progressBar returns a determinate progress bar, e.g. "[=====>    ] 50%".
+func progressBar(current, total int64) string {
+	step := &progressStep{current: current, total: total}
+	percent := step.percent()
+	filled := int(percent * progressBarWidth / 100)
+
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	return fmt.Sprintf("[%s] %d%%", bar, percent)
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	auroraPackage "github.com/logrusorgru/aurora"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProgressTracker returns a tracker with a clock advancing by one
+// second on every read.
+func newTestProgressTracker(b *bytes.Buffer, interactive bool, steps ...string) *progressTracker {
+	now := time.Date(2023, 3, 1, 10, 30, 0, 0, time.UTC)
+	pt := NewProgressTracker(b, steps...).(*progressTracker)
+	pt.interactive = interactive
+	pt.colors = auroraPackage.NewAurora(false)
+	pt.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	return pt
+}
+
+func TestProgressTrackerLogLines(t *testing.T) {
+	var b bytes.Buffer
+	pt := newTestProgressTracker(&b, false, "Create cluster", "Install packages")
+
+	pt.StartStep("Create cluster")
+	pt.SucceedStep("Create cluster")
+	pt.StartStep("Install packages")
+	pt.SetStepProgress("Install packages", 1, 4)
+	pt.SetStepProgress("Install packages", 1, 4)
+	pt.FailStep("Install packages", errors.New("timed out"))
+	pt.Stop()
+
+	require.Equal(t, strings.Join([]string{
+		"2023-03-01T10:30:01Z Create cluster: running",
+		"2023-03-01T10:30:02Z Create cluster: succeeded (1s)",
+		"2023-03-01T10:30:03Z Install packages: running",
+		"2023-03-01T10:30:04Z Install packages: 25% (1/4)",
+		"2023-03-01T10:30:05Z Install packages: failed (2s): timed out",
+		"",
+	}, "\n"), b.String())
+}
+
+func TestProgressTrackerRedraw(t *testing.T) {
+	var b bytes.Buffer
+	pt := newTestProgressTracker(&b, true, "Create cluster", "Install packages")
+	b.Reset()
+
+	pt.mutex.Lock()
+	pt.steps[0].status = StepSucceeded
+	pt.steps[0].started = time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	pt.steps[0].finished = pt.steps[0].started.Add(90 * time.Second)
+	pt.steps[1].status = StepRunning
+	pt.steps[1].started = pt.steps[0].finished
+	pt.steps[1].finished = pt.steps[1].started.Add(5 * time.Second)
+	pt.steps[1].current, pt.steps[1].total = 1, 2
+	pt.redraw()
+	pt.mutex.Unlock()
+
+	require.Equal(t, "\r\x1b[2K✓ Create cluster (1m30s)\n"+
+		"\r\x1b[2K| Install packages (5s) [==========>         ] 50%\n", b.String())
+}
+
+func TestProgressTrackerStepLines(t *testing.T) {
+	var b bytes.Buffer
+	pt := newTestProgressTracker(&b, true)
+
+	pt.StartStep("Create cluster")
+	pt.FailStep("Create cluster", errors.New("no capacity"))
+	pt.AddStep("Delete cluster")
+	pt.Stop()
+
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, "\x1b[2A\r\x1b[2K✗ Create cluster (2s): no capacity", lines[len(lines)-3])
+	require.Equal(t, "\r\x1b[2K- Delete cluster", lines[len(lines)-2])
+}
+
+func TestProgressTrackerRestartedStep(t *testing.T) {
+	var b bytes.Buffer
+	pt := newTestProgressTracker(&b, true, "Create cluster")
+
+	pt.StartStep("Create cluster")
+	pt.SetStepProgress("Create cluster", 1, 2)
+	pt.FailStep("Create cluster", errors.New("no capacity"))
+	pt.StartStep("Create cluster")
+
+	pt.mutex.Lock()
+	step := pt.steps[0]
+	require.True(t, step.finished.IsZero())
+	require.NoError(t, step.err)
+	require.Equal(t, "| Create cluster (2s)", pt.stepLine(step))
+	pt.mutex.Unlock()
+
+	pt.Stop()
+	b.Reset()
+	pt.AddStep("Delete cluster")
+	pt.SucceedStep("Create cluster")
+	require.Empty(t, b.String())
+}
+
+func TestProgressTrackerConcurrentSteps(t *testing.T) {
+	var b bytes.Buffer
+	pt := NewProgressTracker(&b)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("step %d", i)
+			pt.StartStep(name)
+			pt.SetStepProgress(name, 1, 1)
+			pt.SucceedStep(name)
+		}(i)
+	}
+	wg.Wait()
+	pt.Stop()
+
+	require.Equal(t, 30, strings.Count(b.String(), "\n"))
+	require.Equal(t, 10, strings.Count(b.String(), ": succeeded"))
+}
+
+func TestProgressBar(t *testing.T) {
+	require.Equal(t, "[>                   ] 0%", progressBar(0, 10))
+	require.Equal(t, "[=====>              ] 25%", progressBar(1, 4))
+	require.Equal(t, "[====================] 100%", progressBar(12, 10))
+	require.Equal(t, "[>                   ] 0%", progressBar(-5, 10))
+}
@@ -9,10 +9,15 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/mattn/go-isatty"
 )
 
 // PromptConfig is the configuration for a prompt.
 type PromptConfig struct {
+	// ID identifies the prompt in the answers used in non-interactive mode.
+	// The message is used when it is not set.
+	ID string
+
 	// Message to display to user.
 	Message string
 
@@ -37,6 +42,14 @@ func (p *PromptConfig) Run(response interface{}, opts ...PromptOpt) error {
 // Prompt for input, reads input value, without trimming any characters (may include leading/tailing spaces)
 func Prompt(p *PromptConfig, response interface{}, opts ...PromptOpt) error {
 	prompt := translatePromptConfig(p)
+	return askOne(p.ID, prompt, response, opts)
+}
+
+// askOne asks a single question using the prompt options, and any extra
+// survey options. In non-interactive mode, the answer is taken from the
+// answers keyed by id, or from the default of the prompt. When the input is
+// not a terminal, the question is asked one line at a time.
+func askOne(id string, prompt survey.Prompt, response interface{}, opts []PromptOpt, askOpts ...survey.AskOpt) error {
 	options := defaultPromptOptions()
 	for _, opt := range opts {
 		err := opt(options)
@@ -45,8 +58,27 @@ func Prompt(p *PromptConfig, response interface{}, opts ...PromptOpt) error {
 		}
 	}
 
-	surveyOpts := translatePromptOpts(options)
-	return survey.AskOne(prompt, response, surveyOpts...)
+	surveyOpts := append(translatePromptOpts(options), askOpts...)
+	if IsNonInteractive() {
+		return answerNonInteractively(id, prompt, response, options, surveyOpts)
+	}
+	if options.denyOnInvalidAnswer || !isatty.IsTerminal(options.Stdio.In.Fd()) && !isatty.IsCygwinTerminal(options.Stdio.In.Fd()) {
+		// Without a terminal, ask one line at a time
+		if lp := newLinePrompt(prompt); lp != nil {
+			return askLine(lp, response, options, surveyOpts)
+		}
+	}
+
+	question := &survey.Question{Prompt: prompt}
+	if len(options.Transformers) != 0 {
+		question.Transform = func(ans interface{}) interface{} {
+			if s, ok := ans.(string); ok {
+				return options.transform(s)
+			}
+			return nil
+		}
+	}
+	return survey.Ask([]*survey.Question{question}, response, surveyOpts...)
 }
 
 func translatePromptConfig(p *PromptConfig) survey.Prompt {
@@ -112,6 +144,56 @@ type PromptOptions struct {
 	// Standard in/out/error
 	Stdio terminal.Stdio
 	Icons survey.IconSet
+
+	// Validators check the answer, after it is transformed. The user is
+	// asked again until all validators pass.
+	Validators []Validator
+
+	// Transformers change text answers, in order, before they are
+	// validated and returned.
+	Transformers []Transformer
+
+	// PageSize is the number of options shown at once by selections,
+	// unless set by the selection itself.
+	PageSize int
+
+	// HelpInput is the key showing the help of a prompt, '?' by default.
+	HelpInput rune
+
+	// AssumeYes answers confirmations with yes without asking.
+	AssumeYes bool
+
+	// denyOnInvalidAnswer asks confirmations one line at a time, and denies
+	// them when the answer is invalid or cannot be read.
+	denyOnInvalidAnswer bool
+}
+
+// checkAnswer validates an answer which was not given through survey, and
+// returns it transformed.
+func checkAnswer(answer interface{}, options *PromptOptions, surveyOpts []survey.AskOpt) (interface{}, error) {
+	askOptions := &survey.AskOptions{}
+	for _, opt := range surveyOpts {
+		if err := opt(askOptions); err != nil {
+			return nil, err
+		}
+	}
+	for _, validator := range askOptions.Validators {
+		if err := validator(answer); err != nil {
+			return nil, err
+		}
+	}
+	if s, ok := answer.(string); ok {
+		return options.transform(s), nil
+	}
+	return answer, nil
+}
+
+// transform applies the transformers to a text answer.
+func (options *PromptOptions) transform(answer string) string {
+	for _, transformer := range options.Transformers {
+		answer = transformer(answer)
+	}
+	return answer
 }
 
 // PromptOpt is an option for prompts
@@ -126,7 +208,22 @@ func translatePromptOpts(options *PromptOptions) (surveyOpts []survey.AskOpt) {
 		icons.UnmarkedOption = options.Icons.UnmarkedOption
 		icons.SelectFocus = options.Icons.SelectFocus
 	}))
+	if options.PageSize > 0 {
+		surveyOpts = append(surveyOpts, survey.WithPageSize(options.PageSize))
+	}
+	if options.HelpInput != 0 {
+		surveyOpts = append(surveyOpts, survey.WithHelpInput(options.HelpInput))
+	}
 
+	for _, validator := range options.Validators {
+		validator := validator
+		surveyOpts = append(surveyOpts, survey.WithValidator(func(ans interface{}) error {
+			if s, ok := ans.(string); ok {
+				return validator(options.transform(s))
+			}
+			return validator(ans)
+		}))
+	}
 	return
 }
 
@@ -140,3 +237,60 @@ func WithStdio(in terminal.FileReader, out terminal.FileWriter, err io.Writer) P
 		return nil
 	}
 }
+
+// WithInputOutput specifies a reader the answers are read from, and a writer
+// the questions are written to. The answers are read one line at a time,
+// which allows prompts to be scripted, for example in tests.
+func WithInputOutput(in io.Reader, out io.Writer) PromptOpt {
+	return func(options *PromptOptions) error {
+		options.Stdio.In = nonTerminalReader{in}
+		options.Stdio.Out = nonTerminalWriter{out}
+		options.Stdio.Err = out
+		return nil
+	}
+}
+
+// WithIcons specifies the icons used by the prompts.
+func WithIcons(icons survey.IconSet) PromptOpt {
+	return func(options *PromptOptions) error {
+		options.Icons = icons
+		return nil
+	}
+}
+
+// WithPageSize specifies the number of options shown at once by
+// selections, unless set by the selection itself.
+func WithPageSize(pageSize int) PromptOpt {
+	return func(options *PromptOptions) error {
+		options.PageSize = pageSize
+		return nil
+	}
+}
+
+// WithHelpInput specifies the key showing the help of a prompt, '?' by
+// default.
+func WithHelpInput(r rune) PromptOpt {
+	return func(options *PromptOptions) error {
+		options.HelpInput = r
+		return nil
+	}
+}
+
+// WithValidator adds validators checking the answer. When a validator fails,
+// its error is shown and the user is asked again.
+func WithValidator(validators ...Validator) PromptOpt {
+	return func(options *PromptOptions) error {
+		options.Validators = append(options.Validators, validators...)
+		return nil
+	}
+}
+
+// WithTransformer adds transformers changing text answers, such as
+// strings.TrimSpace or strings.ToLower. Transformers are applied in order,
+// before the answer is validated.
+func WithTransformer(transformers ...Transformer) PromptOpt {
+	return func(options *PromptOptions) error {
+		options.Transformers = append(options.Transformers, transformers...)
+		return nil
+	}
+}
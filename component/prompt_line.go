@@ -0,0 +1,258 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+// editorEndLine ends the text entered for an editor prompt read line by line.
+const editorEndLine = "."
+
+// nonTerminalReader adapts a reader for use as the standard input of
+// prompts, which is then read line by line.
+type nonTerminalReader struct {
+	io.Reader
+}
+
+// Fd returns an invalid file descriptor, which is never a terminal.
+func (nonTerminalReader) Fd() uintptr {
+	return ^uintptr(0)
+}
+
+// nonTerminalWriter adapts a writer for use as the standard output of
+// prompts.
+type nonTerminalWriter struct {
+	io.Writer
+}
+
+// Fd returns an invalid file descriptor, which is never a terminal.
+func (nonTerminalWriter) Fd() uintptr {
+	return ^uintptr(0)
+}
+
+// linePrompt is a prompt asked one line at a time, when the input is not a
+// terminal.
+type linePrompt struct {
+	message      string
+	help         string
+	defaultValue string
+	options      []string
+	multiple     bool
+	confirm      bool
+	editor       bool
+	parse        func(line string) (interface{}, error)
+}
+
+// newLinePrompt returns the line prompt for a survey prompt, or nil if the
+// prompt can only be asked through survey.
+func newLinePrompt(prompt survey.Prompt) *linePrompt {
+	text := func(line string) (interface{}, error) { return line, nil }
+
+	switch p := prompt.(type) {
+	case *survey.Input:
+		return &linePrompt{message: p.Message, help: p.Help, defaultValue: p.Default, parse: text}
+	case *survey.Password:
+		return &linePrompt{message: p.Message, help: p.Help, parse: text}
+	case *survey.Editor:
+		return &linePrompt{message: p.Message, help: p.Help, defaultValue: p.Default, editor: true, parse: text}
+	case *survey.Select:
+		lp := &linePrompt{message: p.Message, help: p.Help, options: p.Options}
+		lp.defaultValue = defaultOption(p.Options, p.Default)
+		lp.parse = func(line string) (interface{}, error) {
+			return lineOption(lp.message, lp.options, line)
+		}
+		return lp
+	case *survey.MultiSelect:
+		lp := &linePrompt{message: p.Message, help: p.Help, options: p.Options, multiple: true}
+		var defaults []string
+		switch d := p.Default.(type) {
+		case []string:
+			defaults = d
+		case []int:
+			for _, i := range d {
+				defaults = append(defaults, defaultOption(p.Options, i))
+			}
+		}
+		lp.defaultValue = strings.Join(defaults, ", ")
+		lp.parse = func(line string) (interface{}, error) {
+			answers := []core.OptionAnswer{}
+			for _, value := range strings.Split(line, ",") {
+				if value = strings.TrimSpace(value); value == "" {
+					continue
+				}
+				answer, err := lineOption(lp.message, lp.options, value)
+				if err != nil {
+					return nil, err
+				}
+				answers = append(answers, answer)
+			}
+			return answers, nil
+		}
+		return lp
+	case *survey.Confirm:
+		lp := &linePrompt{message: p.Message, help: p.Help, confirm: true, defaultValue: "n"}
+		if p.Default {
+			lp.defaultValue = "y"
+		}
+		lp.parse = func(line string) (interface{}, error) {
+			return confirmValue(lp.message, line)
+		}
+		return lp
+	}
+	return nil
+}
+
+// askLine asks a prompt one line at a time, asking again until the answer
+// is valid, and writes the answer to response.
+func askLine(lp *linePrompt, response interface{}, options *PromptOptions, surveyOpts []survey.AskOpt) error {
+	helpInput := options.HelpInput
+	if helpInput == 0 {
+		helpInput = '?'
+	}
+	out := options.Stdio.Out
+
+	lp.writeOptions(out, options.Icons)
+	for {
+		lp.writeQuestion(out, options.Icons)
+		line, err := lp.read(options.Stdio.In)
+		if err != nil && lp.confirm && options.denyOnInvalidAnswer {
+			return core.WriteAnswer(response, "", false)
+		}
+		if err != nil {
+			return err
+		}
+
+		if lp.help != "" && line == string(helpInput) {
+			fmt.Fprintf(out, "%s %s\n", options.Icons.Help.Text, lp.help)
+			continue
+		}
+		if line == "" {
+			line = lp.defaultValue
+		}
+
+		answer, err := lp.parse(line)
+		if err != nil && lp.confirm && options.denyOnInvalidAnswer {
+			return core.WriteAnswer(response, "", false)
+		}
+		if err == nil {
+			answer, err = checkAnswer(answer, options, surveyOpts)
+		}
+		if err != nil {
+			fmt.Fprintf(out, "%s %s\n", options.Icons.Error.Text, err)
+			continue
+		}
+		return core.WriteAnswer(response, "", answer)
+	}
+}
+
+// writeOptions writes the numbered options of a selection.
+func (lp *linePrompt) writeOptions(out io.Writer, icons survey.IconSet) {
+	if len(lp.options) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "%s %s\n", icons.Question.Text, lp.message)
+	for i, option := range lp.options {
+		fmt.Fprintf(out, "  %d) %s\n", i+1, option)
+	}
+}
+
+func (lp *linePrompt) writeQuestion(out io.Writer, icons survey.IconSet) {
+	question := fmt.Sprintf("%s %s", icons.Question.Text, lp.message)
+	switch {
+	case lp.multiple:
+		question = "Enter the options, separated by commas"
+	case len(lp.options) > 0:
+		question = "Enter an option"
+	}
+
+	switch {
+	case lp.confirm && lp.defaultValue == "y":
+		question += " [Y/n]"
+	case lp.confirm:
+		question += " [y/N]"
+	case lp.editor:
+		question += fmt.Sprintf(" (end with a line containing only %q)", editorEndLine)
+	case lp.defaultValue != "":
+		question += fmt.Sprintf(" [%s]", lp.defaultValue)
+	}
+
+	if lp.editor {
+		fmt.Fprintln(out, question)
+	} else {
+		fmt.Fprintf(out, "%s: ", question)
+	}
+}
+
+// read reads the answer, a single line, or the lines up to the end line for
+// editor prompts.
+func (lp *linePrompt) read(in io.Reader) (string, error) {
+	if !lp.editor {
+		line, err := readLine(in)
+		if err != nil && (err != io.EOF || line == "") {
+			return "", inputRequiredError(lp.message)
+		}
+		return strings.TrimRight(line, "\r"), nil
+	}
+
+	var lines []string
+	for {
+		line, err := readLine(in)
+		line = strings.TrimRight(line, "\r")
+		if line == editorEndLine {
+			break
+		}
+		if err != nil {
+			if err != io.EOF {
+				return "", inputRequiredError(lp.message)
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			break
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// lineOption returns the option matching an answer given as the option
+// text, or else as the option number, starting from 1.
+func lineOption(message string, options []string, answer string) (core.OptionAnswer, error) {
+	if answer == "" {
+		return core.OptionAnswer{}, inputRequiredError(message)
+	}
+	for i, option := range options {
+		if option == answer {
+			return core.OptionAnswer{Value: option, Index: i}, nil
+		}
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+		return core.OptionAnswer{Value: options[n-1], Index: n - 1}, nil
+	}
+	return optionAnswer(message, options, answer)
+}
+
+// defaultOption returns the text of the default of a selection, given as
+// the option text or index.
+func defaultOption(options []string, defaultValue interface{}) string {
+	switch d := defaultValue.(type) {
+	case string:
+		return d
+	case int:
+		if d >= 0 && d < len(options) {
+			return options[d]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// scripted returns the prompt options reading the given lines, and the
+// buffer the prompts are written to.
+func scripted(lines ...string) (PromptOpt, *bytes.Buffer) {
+	var out bytes.Buffer
+	return WithInputOutput(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out), &out
+}
+
+func TestLinePromptInput(t *testing.T) {
+	io, out := scripted("?", "", "my-context")
+
+	var response string
+	err := Prompt(&PromptConfig{Message: "Context name", Help: "The name of the context"}, &response,
+		io, WithValidator(Required))
+	require.NoError(t, err)
+	require.Equal(t, "my-context", response)
+	require.Equal(t, "? Context name: ? The name of the context\n"+
+		"? Context name: X a value is required\n"+
+		"? Context name: ", out.String())
+}
+
+func TestLinePromptDefault(t *testing.T) {
+	io, out := scripted("")
+
+	var response string
+	require.NoError(t, Ask(&QuestionConfig{Message: "Endpoint"}, &response, io, WithTransformer(strings.ToUpper)))
+	require.Equal(t, "", response)
+	require.Equal(t, "? Endpoint: ", out.String())
+
+	io, _ = scripted("")
+	require.NoError(t, Prompt(&PromptConfig{Message: "Namespace", Default: "default"}, &response, io))
+	require.Equal(t, "default", response)
+}
+
+func TestLinePromptSelect(t *testing.T) {
+	io, out := scripted("3", "2")
+
+	var response string
+	require.NoError(t, Select(&SelectConfig{Message: "Kind", Options: []string{"tkg", "tmc"}, Default: "tkg"}, &response, io))
+	require.Equal(t, "tmc", response)
+	require.Equal(t, "? Kind\n"+
+		"  1) tkg\n"+
+		"  2) tmc\n"+
+		"Enter an option [tkg]: X invalid answer \"3\" for \"Kind\", expected one of tkg, tmc\n"+
+		"Enter an option [tkg]: ", out.String())
+
+	io, _ = scripted("")
+	require.NoError(t, Select(&SelectConfig{Message: "Kind", Options: []string{"tkg", "tmc"}, Default: "tkg"}, &response, io))
+	require.Equal(t, "tkg", response)
+}
+
+func TestLinePromptMultiSelect(t *testing.T) {
+	io, out := scripted("1", "cluster, 3")
+
+	var response []string
+	require.NoError(t, MultiSelect(&MultiSelectConfig{Message: "Plugins", Options: []string{"cluster", "package", "secret"}, MinSelections: 2}, &response, io))
+	require.Equal(t, []string{"cluster", "secret"}, response)
+	require.Contains(t, out.String(), "X select at least 2 option(s)\n")
+}
+
+func TestLinePromptConfirm(t *testing.T) {
+	var confirmed bool
+	io, out := scripted("")
+	require.NoError(t, Confirm(&ConfirmConfig{Message: "Delete?", Default: true}, &confirmed, io))
+	require.True(t, confirmed)
+	require.Equal(t, "? Delete? [Y/n]: ", out.String())
+
+	io, _ = scripted("NO")
+	require.NoError(t, Confirm(&ConfirmConfig{Message: "Delete?", Default: true}, &confirmed, io))
+	require.False(t, confirmed)
+}
+
+func TestLinePromptEditor(t *testing.T) {
+	io, _ := scripted("kind: Cluster", "metadata:", "  name: test", ".")
+
+	var spec string
+	require.NoError(t, Editor(&EditorConfig{Message: "Spec"}, &spec, io))
+	require.Equal(t, "kind: Cluster\nmetadata:\n  name: test\n", spec)
+}
+
+func TestLinePromptNoInput(t *testing.T) {
+	var out bytes.Buffer
+	var response string
+	err := Prompt(&PromptConfig{Message: "Name"}, &response, WithInputOutput(strings.NewReader(""), &out))
+	require.EqualError(t, err, "input required: Name")
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,3 +80,92 @@ func Test_PromptOptions(t *testing.T) {
 	assert.Equal("cyan+b", options.Icons.Question.Format)
 	assert.Equal(2, len(opts))
 }
+
+func Test_translateMultiSelectConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := MultiSelectConfig{
+		Message:  "Pick some cards",
+		Options:  []string{"one", "two", "three"},
+		Default:  []string{"two"},
+		PageSize: 2,
+	}
+
+	prompt, ok := translateMultiSelectConfig(&config).(*survey.MultiSelect)
+	assert.True(ok)
+	assert.Equal(config.Options, prompt.Options)
+	assert.Equal([]string{"two"}, prompt.Default)
+	assert.Equal(2, prompt.PageSize)
+
+	prompt, ok = translateMultiSelectConfig(&MultiSelectConfig{Options: config.Options}).(*survey.MultiSelect)
+	assert.True(ok)
+	assert.Nil(prompt.Default)
+}
+
+func Test_MultiSelectInvalidLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	var response []string
+	err := MultiSelect(&MultiSelectConfig{Options: []string{"one", "two", "three"}, MinSelections: 2, MaxSelections: 1}, &response)
+	assert.EqualError(err, "minimum selections 2 is greater than maximum selections 1")
+
+	err = MultiSelect(&MultiSelectConfig{Options: []string{"one"}, MinSelections: 2}, &response)
+	assert.EqualError(err, "minimum selections 2 is greater than the number of options 1")
+}
+
+func Test_selectionLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	answers := func(n int) []core.OptionAnswer {
+		return make([]core.OptionAnswer, n)
+	}
+	validate := selectionLimits(1, 2)
+	assert.EqualError(validate(answers(0)), "select at least 1 option(s)")
+	assert.NoError(validate(answers(1)))
+	assert.NoError(validate(answers(2)))
+	assert.EqualError(validate(answers(3)), "select at most 2 option(s)")
+
+	assert.NoError(selectionLimits(0, 0)(answers(0)))
+}
+
+func Test_translateConfirmConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	prompt, ok := translateConfirmConfig(&ConfirmConfig{Message: "Delete the cluster?", Default: true}).(*survey.Confirm)
+	assert.True(ok)
+	assert.Equal("Delete the cluster?", prompt.Message)
+	assert.True(prompt.Default)
+}
+
+func Test_translateEditorConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := EditorConfig{
+		Message:  "Edit the cluster spec",
+		Default:  "kind: Cluster\n",
+		FileName: "*.yaml",
+	}
+
+	prompt, ok := translateEditorConfig(&config).(*survey.Editor)
+	assert.True(ok)
+	assert.Equal("*.yaml", prompt.FileName)
+	assert.Equal("kind: Cluster\n", prompt.Default)
+	assert.True(prompt.AppendDefault)
+}
+
+func Test_PromptOptionsPageSizeAndHelp(t *testing.T) {
+	assert := assert.New(t)
+
+	options := defaultPromptOptions()
+	for _, opt := range []PromptOpt{WithPageSize(5), WithHelpInput('h')} {
+		assert.NoError(opt(options))
+	}
+	assert.Equal(4, len(translatePromptOpts(options)))
+
+	askOptions := &survey.AskOptions{}
+	for _, opt := range translatePromptOpts(options) {
+		assert.NoError(opt(askOptions))
+	}
+	assert.Equal(5, askOptions.PromptConfig.PageSize)
+	assert.Equal("h", askOptions.PromptConfig.HelpInput)
+}
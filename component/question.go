@@ -9,15 +9,19 @@ import (
 
 // QuestionConfig stores config for prompting a CLI question.
 type QuestionConfig struct {
+	// ID identifies the question in the answers used in non-interactive
+	// mode. The message is used when it is not set.
+	ID string
+
 	Message string
 }
 
 // Run asks a question.
-func (q *QuestionConfig) Run(response interface{}) error {
-	return Ask(q, response)
+func (q *QuestionConfig) Run(response interface{}, opts ...PromptOpt) error {
+	return Ask(q, response, opts...)
 }
 
 // Ask asks a questions and lets the user select an option.
-func Ask(q *QuestionConfig, response interface{}) error {
-	return survey.AskOne(&survey.Input{Message: q.Message}, response)
+func Ask(q *QuestionConfig, response interface{}, opts ...PromptOpt) error {
+	return askOne(q.ID, &survey.Input{Message: q.Message}, response, opts)
 }
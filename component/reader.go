@@ -43,7 +43,8 @@ func readFromFile(filePath string) ([]byte, error) {
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error reading from input file %s", filePath))
 	}
-	log.Infof("read object --> \n---\n%s\n---\n", buf.String())
+	// The content is not logged, as it may hold credentials
+	log.V(6).Infof("read %d bytes from input file %s", buf.Len(), filePath)
 	return buf.Bytes(), nil
 }
 
@@ -0,0 +1,325 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+)
+
+var (
+	envReferenceRegexp  = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+	documentSeparatorRe = regexp.MustCompile(`^---(?:\s+(.*))?$`)
+)
+
+// InputOption is an option for reading input documents.
+type InputOption func(*inputOptions)
+
+type inputOptions struct {
+	expandEnv bool
+	strict    bool
+}
+
+// WithEnvExpansion expands ${VAR} references in the scalar values of the
+// input, from the environment variables, or else from the environment
+// variables set in the Tanzu configuration, see config.GetEnvConfigurations.
+// References to variables that are not set fail. The input is parsed before
+// expanding the references, so that the values of the variables cannot change
+// the structure of the input, and references in keys and comments are kept.
+// $${VAR} is kept as ${VAR}. The documents read are re-encoded as YAML.
+func WithEnvExpansion() InputOption {
+	return func(options *inputOptions) {
+		options.expandEnv = true
+	}
+}
+
+// WithStrictDecoding fails on fields of the input which are not in the type
+// decoded into.
+func WithStrictDecoding() InputOption {
+	return func(options *inputOptions) {
+		options.strict = true
+	}
+}
+
+// ReadInputDocuments reads the documents of a multi-document YAML stream, or
+// of a JSON document, from file or std input. Empty documents are skipped.
+func ReadInputDocuments(filePath string, opts ...InputOption) ([][]byte, error) {
+	options := &inputOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	data, err := ReadInput(filePath)
+	if err != nil {
+		return nil, err
+	}
+	documents := splitDocuments(data)
+	if options.expandEnv {
+		if err := expandEnvReferences(documents); err != nil {
+			return nil, err
+		}
+	}
+	return documents, nil
+}
+
+// DecodeInput reads JSON or YAML from file or std input and decodes it into
+// out. Fields are matched using their json tags. When out points to a slice,
+// each document of a multi-document stream is decoded as an element of the
+// slice. Otherwise the input must hold a single document.
+func DecodeInput(filePath string, out interface{}, opts ...InputOption) error {
+	options := &inputOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	documents, err := ReadInputDocuments(filePath, opts...)
+	if err != nil {
+		return err
+	}
+	return decodeDocuments(documents, out, options.strict)
+}
+
+func decodeDocuments(documents [][]byte, out interface{}, strict bool) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return errors.Errorf("cannot decode into %T, a pointer is required", out)
+	}
+
+	isSlice := target.Elem().Kind() == reflect.Slice
+	if len(documents) == 1 && (!isSlice || isSequence(documents[0])) {
+		return decodeDocument(documents[0], out, strict)
+	}
+	if !isSlice {
+		return errors.Errorf("cannot decode %d documents into %T, a pointer to a slice is required", len(documents), out)
+	}
+
+	slice := reflect.MakeSlice(target.Elem().Type(), len(documents), len(documents))
+	for i, document := range documents {
+		if err := decodeDocument(document, slice.Index(i).Addr().Interface(), strict); err != nil {
+			return errors.WithMessagef(err, "error decoding document %d", i+1)
+		}
+	}
+	target.Elem().Set(slice)
+	return nil
+}
+
+// decodeDocument decodes a JSON or YAML document into out through JSON, so
+// that the json tags of the fields are used.
+func decodeDocument(document []byte, out interface{}, strict bool) error {
+	var value interface{}
+	if err := yaml.Unmarshal(document, &value); err != nil {
+		return errors.Wrap(err, "error parsing the input")
+	}
+	value, err := toJSONCompatible(value)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "error converting the input")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(out); err != nil {
+		return errors.Wrap(err, "error decoding the input")
+	}
+	return nil
+}
+
+// toJSONCompatible converts the maps decoded from YAML, which may have keys
+// of any type, to maps with string keys.
+func toJSONCompatible(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			converted, err := toJSONCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = converted
+		}
+		return v, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted, err := toJSONCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case string, int, int64, uint64, float64, bool:
+				m[toString(key)] = converted
+			default:
+				return nil, errors.Errorf("unsupported map key %v of type %T", key, key)
+			}
+		}
+		return m, nil
+	case []interface{}:
+		for i, item := range v {
+			converted, err := toJSONCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+func toString(value interface{}) string {
+	data, _ := json.Marshal(value)
+	return strings.Trim(string(data), `"`)
+}
+
+// isSequence returns true if the document is a YAML or JSON list.
+func isSequence(document []byte) bool {
+	var node yaml.Node
+	if err := yaml.Unmarshal(document, &node); err != nil || len(node.Content) == 0 {
+		return false
+	}
+	return node.Content[0].Kind == yaml.SequenceNode
+}
+
+// splitDocuments splits a multi-document YAML stream on "---" markers, and
+// skips the documents holding only comments or blank lines. Anything following
+// the marker on its line, such as a tag or a block scalar indicator, is kept as
+// the first line of the next document.
+func splitDocuments(data []byte) [][]byte {
+	var documents [][]byte
+	var current bytes.Buffer
+	flush := func() {
+		if hasContent(current.Bytes()) {
+			documents = append(documents, append([]byte(nil), current.Bytes()...))
+		}
+		current.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := documentSeparatorRe.FindStringSubmatch(strings.TrimRight(line, "\r")); match != nil {
+			flush()
+			if match[1] == "" {
+				continue
+			}
+			line = match[1]
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	flush()
+	return documents
+}
+
+func hasContent(document []byte) bool {
+	for _, line := range strings.Split(string(document), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return true
+		}
+	}
+	return false
+}
+
+// expandEnvReferences replaces the ${VAR} references in the scalar values of
+// the documents with the value of the variables, from the environment, or else
+// from the Tanzu configuration, and re-encodes the documents.
+func expandEnvReferences(documents [][]byte) error {
+	expander := &envExpander{missing: map[string]struct{}{}}
+	for i, document := range documents {
+		var node yaml.Node
+		if err := yaml.Unmarshal(document, &node); err != nil {
+			return errors.Wrap(err, "error parsing the input")
+		}
+		expander.expandNode(&node)
+
+		var b bytes.Buffer
+		encoder := yaml.NewEncoder(&b)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return errors.Wrap(err, "error encoding the input")
+		}
+		documents[i] = b.Bytes()
+	}
+
+	if len(expander.missing) != 0 {
+		names := make([]string, 0, len(expander.missing))
+		for name := range expander.missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return errors.Errorf("environment variables referenced in the input are not set: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// envExpander expands the env references, recording the variables not set
+type envExpander struct {
+	configEnvs map[string]string
+	missing    map[string]struct{}
+}
+
+// expandNode expands the env references in the scalar values of the node,
+// skipping the keys of the mappings.
+func (e *envExpander) expandNode(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, content := range node.Content {
+			e.expandNode(content)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			e.expandNode(node.Content[i])
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() != "!!str" {
+			return
+		}
+		value := e.expand(node.Value)
+		if value == node.Value {
+			return
+		}
+		node.Value = value
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			// The type of a plain value is resolved from the expanded value
+			node.Tag = ""
+		}
+	}
+}
+
+func (e *envExpander) expand(value string) string {
+	return envReferenceRegexp.ReplaceAllStringFunc(value, func(reference string) string {
+		if strings.HasPrefix(reference, "$$") {
+			return reference[1:]
+		}
+		name := envReferenceRegexp.FindStringSubmatch(reference)[1]
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+		if e.configEnvs == nil {
+			e.configEnvs = config.GetEnvConfigurations()
+		}
+		if value, ok := e.configEnvs[name]; ok {
+			return value
+		}
+		e.missing[name] = struct{}{}
+		return reference
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+)
+
+type decodedCluster struct {
+	Name     string            `json:"name"`
+	Version  string            `json:"version,omitempty"`
+	Replicas int               `json:"replicas,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+func writeInput(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "input.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadInputDocuments(t *testing.T) {
+	path := writeInput(t, "---\nname: a\n--- # second\n# only a comment\n---\nname: b\n...\n---\n\n")
+
+	documents, err := ReadInputDocuments(path)
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	require.Equal(t, "name: a\n", string(documents[0]))
+	require.Equal(t, "name: b\n...\n", string(documents[1]))
+}
+
+func TestReadInputDocumentsSeparatorLineContent(t *testing.T) {
+	path := writeInput(t, "--- |\n  text\n--- !cluster\nname: a\n--- {name: b}\n")
+
+	documents, err := ReadInputDocuments(path)
+	require.NoError(t, err)
+	require.Len(t, documents, 3)
+	require.Equal(t, "|\n  text\n", string(documents[0]))
+	require.Equal(t, "!cluster\nname: a\n", string(documents[1]))
+	require.Equal(t, "{name: b}\n", string(documents[2]))
+
+	var clusters []decodedCluster
+	require.NoError(t, DecodeInput(writeInput(t, "--- {name: a}\n--- {name: b}\n"), &clusters))
+	require.Equal(t, []decodedCluster{{Name: "a"}, {Name: "b"}}, clusters)
+}
+
+func TestDecodeInput(t *testing.T) {
+	var cluster decodedCluster
+	require.NoError(t, DecodeInput(writeInput(t, "name: a\nreplicas: 3\nlabels:\n  1: one\n"), &cluster))
+	require.Equal(t, decodedCluster{Name: "a", Replicas: 3, Labels: map[string]string{"1": "one"}}, cluster)
+
+	require.NoError(t, DecodeInput(writeInput(t, `{"name": "json", "version": "v1"}`), &cluster))
+	require.Equal(t, "json", cluster.Name)
+	require.Equal(t, "v1", cluster.Version)
+
+	var clusters []decodedCluster
+	require.NoError(t, DecodeInput(writeInput(t, "name: a\n---\nname: b\n"), &clusters))
+	require.Equal(t, []decodedCluster{{Name: "a"}, {Name: "b"}}, clusters)
+
+	require.NoError(t, DecodeInput(writeInput(t, "- name: c\n- name: d\n"), &clusters))
+	require.Equal(t, []decodedCluster{{Name: "c"}, {Name: "d"}}, clusters)
+
+	err := DecodeInput(writeInput(t, "name: a\n---\nname: b\n"), &cluster)
+	require.ErrorContains(t, err, "cannot decode 2 documents")
+	require.ErrorContains(t, DecodeInput(writeInput(t, "name: a\n"), cluster), "a pointer is required")
+	require.ErrorContains(t, DecodeInput(writeInput(t, "name: [a\n"), &cluster), "error parsing the input")
+	require.ErrorContains(t, DecodeInput(writeInput(t, "name: a\n---\nreplicas: many\n"), &clusters), "error decoding document 2")
+}
+
+func TestDecodeInputStrict(t *testing.T) {
+	path := writeInput(t, "name: a\nunknown: b\n")
+
+	var cluster decodedCluster
+	require.NoError(t, DecodeInput(path, &cluster))
+	require.ErrorContains(t, DecodeInput(path, &cluster, WithStrictDecoding()), `unknown field "unknown"`)
+}
+
+func TestDecodeInputEnvExpansion(t *testing.T) {
+	t.Setenv("TEST_CLUSTER_NAME", "from-env")
+	path := writeInput(t, "name: ${TEST_CLUSTER_NAME}\nversion: $TEST_CLUSTER_NAME\n")
+
+	var cluster decodedCluster
+	require.NoError(t, DecodeInput(path, &cluster))
+	require.Equal(t, "${TEST_CLUSTER_NAME}", cluster.Name)
+
+	require.NoError(t, DecodeInput(path, &cluster, WithEnvExpansion()))
+	require.Equal(t, "from-env", cluster.Name)
+	require.Equal(t, "$TEST_CLUSTER_NAME", cluster.Version)
+
+	path = writeInput(t, "name: ${TEST_UNSET_B}${TEST_UNSET_A}\n")
+	require.EqualError(t, DecodeInput(path, &cluster, WithEnvExpansion()),
+		"environment variables referenced in the input are not set: TEST_UNSET_A, TEST_UNSET_B")
+}
+
+func TestDecodeInputEnvExpansionKeepsStructure(t *testing.T) {
+	t.Setenv("TEST_CLUSTER_NAME", "injected\nreplicas: 5\nlabels: {a: b}")
+	t.Setenv("TEST_CLUSTER_REPLICAS", "3")
+	path := writeInput(t, "# ${TEST_UNSET_IN_COMMENT}\nname: ${TEST_CLUSTER_NAME}\nreplicas: ${TEST_CLUSTER_REPLICAS}\nversion: $${TEST_CLUSTER_NAME}\n")
+
+	var cluster decodedCluster
+	require.NoError(t, DecodeInput(path, &cluster, WithEnvExpansion()))
+	require.Equal(t, decodedCluster{
+		Name:     "injected\nreplicas: 5\nlabels: {a: b}",
+		Version:  "${TEST_CLUSTER_NAME}",
+		Replicas: 3,
+	}, cluster)
+
+	// Quoted values stay strings
+	path = writeInput(t, `{"name": "a", "version": "${TEST_CLUSTER_REPLICAS}"}`)
+	require.NoError(t, DecodeInput(path, &cluster, WithEnvExpansion()))
+	require.Equal(t, "3", cluster.Version)
+}
+
+func TestDecodeInputEnvExpansionFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfg, []byte("clientOptions:\n  env:\n    TEST_CONFIG_VERSION: v1.2.3\n"), 0o600))
+	t.Setenv(config.EnvConfigKey, cfg)
+	t.Setenv(config.EnvConfigNextGenKey, filepath.Join(dir, "config-ng.yaml"))
+	t.Setenv(config.EnvConfigMetadataKey, filepath.Join(dir, "config-metadata.yaml"))
+
+	var cluster decodedCluster
+	require.NoError(t, DecodeInput(writeInput(t, "name: a\nversion: ${TEST_CONFIG_VERSION}\n"), &cluster, WithEnvExpansion()))
+	require.Equal(t, "v1.2.3", cluster.Version)
+}
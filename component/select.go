@@ -9,6 +9,10 @@ import (
 
 // SelectConfig is the configuration for a selection.
 type SelectConfig struct {
+	// ID identifies the prompt in the answers used in non-interactive mode.
+	// The message is used when it is not set.
+	ID string
+
 	// Message to display to user.
 	Message string
 
@@ -29,14 +33,14 @@ type SelectConfig struct {
 }
 
 // Run the selection.
-func (p *SelectConfig) Run(response interface{}) error {
-	return Select(p, response)
+func (p *SelectConfig) Run(response interface{}, opts ...PromptOpt) error {
+	return Select(p, response, opts...)
 }
 
 // Select an option.
-func Select(p *SelectConfig, response interface{}) error {
+func Select(p *SelectConfig, response interface{}, opts ...PromptOpt) error {
 	prompt := translateSelectConfig(p)
-	return survey.AskOne(prompt, response)
+	return askOne(p.ID, prompt, response, opts)
 }
 
 func translateSelectConfig(p *SelectConfig) survey.Prompt {
@@ -0,0 +1,184 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/briandowns/spinner"
+	auroraPackage "github.com/logrusorgru/aurora"
+)
+
+const (
+	spinnerInterval = 100 * time.Millisecond
+
+	clearLine  = "\r\x1b[2K"
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+)
+
+// Spinner shows an animation next to a message while an operation is in
+// progress. The animation is only shown when the spinner writes to a
+// terminal, the final success or failure message is always written.
+type Spinner interface {
+	// Start starts the animation.
+	Start()
+	// SetText changes the message shown next to the animation.
+	SetText(text string)
+	// Stop stops the animation and clears it.
+	Stop()
+	// Succeed stops the animation and writes a check mark followed by the
+	// message.
+	Succeed(message string)
+	// Fail stops the animation and writes a cross followed by the message.
+	Fail(message string)
+	// Active returns true if the animation is running.
+	Active() bool
+}
+
+// SpinnerOption configures a Spinner.
+type SpinnerOption func(*textSpinner)
+
+// WithSpinnerWriter sets the writer of the spinner, os.Stderr by default, so
+// that the spinner does not get mixed with output written to os.Stdout.
+func WithSpinnerWriter(w io.Writer) SpinnerOption {
+	return func(s *textSpinner) {
+		s.out = w
+	}
+}
+
+// textSpinner is our internal implementation.
+type textSpinner struct {
+	mutex       sync.Mutex
+	ctx         context.Context
+	out         io.Writer
+	text        string
+	interactive bool
+	colors      auroraPackage.Aurora
+	frame       int
+	active      bool
+	done        chan struct{}
+}
+
+// NewSpinner returns a Spinner showing text. The spinner stops when ctx is
+// cancelled, e.g. by a context returned by signal.NotifyContext when the
+// program is interrupted. The spinner does not handle signals itself.
+func NewSpinner(ctx context.Context, text string, opts ...SpinnerOption) Spinner {
+	s := &textSpinner{
+		ctx:  ctx,
+		out:  os.Stderr,
+		text: text,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.ctx == nil {
+		s.ctx = context.Background()
+	}
+	s.interactive = isTerminal(s.out)
+	s.colors = auroraPackage.NewAurora(isColorEnabled(s.out))
+	return s
+}
+
+// Start starts the animation, if writing to a terminal.
+func (s *textSpinner) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.active || !s.interactive || s.ctx.Err() != nil {
+		return
+	}
+	s.active = true
+	s.done = make(chan struct{})
+	fmt.Fprint(s.out, hideCursor)
+	s.draw()
+	go s.run(s.done)
+}
+
+func (s *textSpinner) run(done chan struct{}) {
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.mutex.Lock()
+			if s.active {
+				s.frame++
+				s.draw()
+			}
+			s.mutex.Unlock()
+		case <-s.ctx.Done():
+			s.Stop()
+			return
+		case <-done:
+			return
+		}
+	}
+}
+
+// SetText changes the message shown next to the animation.
+func (s *textSpinner) SetText(text string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.text = text
+	if s.active {
+		s.draw()
+	}
+}
+
+// Stop stops the animation and clears it.
+func (s *textSpinner) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stop()
+}
+
+// Succeed stops the animation and writes a check mark followed by the
+// message.
+func (s *textSpinner) Succeed(message string) {
+	s.finish(s.colors.Green("✓").String(), message)
+}
+
+// Fail stops the animation and writes a cross followed by the message.
+func (s *textSpinner) Fail(message string) {
+	s.finish(s.colors.Red("✗").String(), message)
+}
+
+// Active returns true if the animation is running.
+func (s *textSpinner) Active() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.active
+}
+
+func (s *textSpinner) finish(mark, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stop()
+	fmt.Fprintf(s.out, "%s %s\n", mark, message)
+}
+
+func (s *textSpinner) stop() {
+	if !s.active {
+		return
+	}
+	s.active = false
+	close(s.done)
+	fmt.Fprint(s.out, clearLine+showCursor)
+}
+
+func (s *textSpinner) draw() {
+	frames := spinner.CharSets[9]
+	fmt.Fprintf(s.out, "%s%s %s", clearLine, s.colors.Bold(frames[s.frame%len(frames)]), s.text)
+}
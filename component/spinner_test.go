@@ -0,0 +1,97 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe to write from the spinner goroutine.
+type syncBuffer struct {
+	mutex sync.Mutex
+	b     bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mutex.Lock()
+	defer sb.mutex.Unlock()
+	return sb.b.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mutex.Lock()
+	defer sb.mutex.Unlock()
+	return sb.b.String()
+}
+
+// newTestSpinner returns a spinner animated as if writing to a terminal.
+func newTestSpinner(ctx context.Context, out *syncBuffer, text string) *textSpinner {
+	s := NewSpinner(ctx, text, WithSpinnerWriter(out)).(*textSpinner)
+	s.interactive = true
+	return s
+}
+
+func TestSpinnerNotTerminal(t *testing.T) {
+	var b bytes.Buffer
+	s := NewSpinner(context.Background(), "Installing", WithSpinnerWriter(&b))
+
+	s.Start()
+	require.False(t, s.Active())
+	s.Succeed("Installed")
+	s.Fail("Failed to configure")
+
+	require.Equal(t, "✓ Installed\n✗ Failed to configure\n", b.String())
+}
+
+func TestSpinnerSucceed(t *testing.T) {
+	var b syncBuffer
+	s := newTestSpinner(context.Background(), &b, "Installing")
+
+	s.Start()
+	require.True(t, s.Active())
+	s.SetText("Still installing")
+	s.Succeed("Installed")
+	require.False(t, s.Active())
+
+	output := b.String()
+	require.True(t, strings.HasPrefix(output, hideCursor+clearLine+"| Installing"), "%q", output)
+	require.Contains(t, output, clearLine+"| Still installing")
+	require.True(t, strings.HasSuffix(output, clearLine+showCursor+"✓ Installed\n"), "%q", output)
+}
+
+func TestSpinnerStopsOnContextCancel(t *testing.T) {
+	var b syncBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	s := newTestSpinner(ctx, &b, "Waiting")
+
+	s.Start()
+	require.True(t, s.Active())
+	cancel()
+
+	require.Eventually(t, func() bool { return !s.Active() }, time.Second, 10*time.Millisecond)
+	require.True(t, strings.HasSuffix(b.String(), clearLine+showCursor))
+
+	// A cancelled spinner does not start again
+	s.Start()
+	require.False(t, s.Active())
+}
+
+func TestOutputWriterWithSpinnerOptions(t *testing.T) {
+	var out, status bytes.Buffer
+	ows, err := NewOutputWriterWithSpinnerOptions(context.Background(), &out, string(TableOutputType), "Loading", true, []SpinnerOption{WithSpinnerWriter(&status)}, "Name")
+	require.NoError(t, err)
+
+	ows.AddRow("one")
+	ows.RenderWithSpinner()
+
+	require.Contains(t, out.String(), "one")
+	require.Empty(t, status.String())
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/pkg/errors"
+)
+
+// Validator checks an answer, returning an error to show to the user if it
+// is not valid. Text answers are strings, selected options are
+// core.OptionAnswer values, or a slice of them for multi-selects.
+type Validator func(answer interface{}) error
+
+// Transformer changes a text answer.
+type Transformer func(answer string) string
+
+const maxContextNameLength = 63
+
+var (
+	contextNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
+	dnsLabelRegexp    = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+)
+
+// StringValidator returns a Validator calling fn with the answer as text.
+func StringValidator(fn func(answer string) error) Validator {
+	return func(answer interface{}) error {
+		return fn(answerString(answer))
+	}
+}
+
+// Required fails on empty or blank text answers, and on multi-selects with
+// no selected options.
+func Required(answer interface{}) error {
+	if answers, ok := answer.([]core.OptionAnswer); ok {
+		if len(answers) == 0 {
+			return errors.New("select at least one option")
+		}
+		return nil
+	}
+	if answer == nil || strings.TrimSpace(answerString(answer)) == "" {
+		return errors.New("a value is required")
+	}
+	return nil
+}
+
+// MatchesRegexp fails on answers not matching re. The description of the
+// expected value is used in the error, e.g. "a semantic version".
+func MatchesRegexp(re *regexp.Regexp, description string) Validator {
+	return StringValidator(func(answer string) error {
+		if !re.MatchString(answer) {
+			return errors.Errorf("must be %s", description)
+		}
+		return nil
+	})
+}
+
+// MinLength fails on answers shorter than length characters.
+func MinLength(length int) Validator {
+	return StringValidator(func(answer string) error {
+		if utf8.RuneCountInString(answer) < length {
+			return errors.Errorf("must be at least %d characters long", length)
+		}
+		return nil
+	})
+}
+
+// MaxLength fails on answers longer than length characters.
+func MaxLength(length int) Validator {
+	return StringValidator(func(answer string) error {
+		if utf8.RuneCountInString(answer) > length {
+			return errors.Errorf("must be at most %d characters long", length)
+		}
+		return nil
+	})
+}
+
+// NumberInRange fails on answers that are not numbers between min and max,
+// inclusive.
+func NumberInRange(min, max float64) Validator {
+	return StringValidator(func(answer string) error {
+		n, err := strconv.ParseFloat(strings.TrimSpace(answer), 64)
+		if err != nil {
+			return errors.Errorf("%q is not a number", answer)
+		}
+		if n < min || n > max {
+			return errors.Errorf("must be between %v and %v", min, max)
+		}
+		return nil
+	})
+}
+
+// ValidContextName fails on answers that can not be used as the name of a
+// context: names start and end with a letter or digit, only contain letters,
+// digits, '-', '_' and '.', and are at most 63 characters long.
+func ValidContextName(answer interface{}) error {
+	name := answerString(answer)
+	if len(name) > maxContextNameLength {
+		return errors.Errorf("context name must be at most %d characters long", maxContextNameLength)
+	}
+	if !contextNameRegexp.MatchString(name) {
+		return errors.Errorf("invalid context name %q, it must start and end with a letter or digit, and only contain letters, digits, '-', '_' and '.'", name)
+	}
+	return nil
+}
+
+// ValidDNSName fails on answers that are not DNS names as defined by RFC
+// 1123, such as Kubernetes resource names: lower case labels of letters,
+// digits and '-', separated by '.'.
+func ValidDNSName(answer interface{}) error {
+	name := answerString(answer)
+	if err := validateHostname(name); err != nil {
+		return errors.Wrapf(err, "invalid DNS name %q", name)
+	}
+	return nil
+}
+
+// ValidEndpoint fails on answers that are not endpoints, given either as an
+// http or https URL, or as a host with an optional port, e.g.
+// "https://api.example.com" or "api.example.com:443".
+func ValidEndpoint(answer interface{}) error {
+	endpoint := strings.TrimSpace(answerString(answer))
+	if endpoint == "" {
+		return errors.New("an endpoint is required")
+	}
+
+	host := endpoint
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return errors.Errorf("invalid endpoint %q", endpoint)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid endpoint %q, the scheme must be http or https", endpoint)
+		}
+		host = u.Host
+	}
+
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			return errors.Errorf("invalid endpoint %q, invalid port %q", endpoint, port)
+		}
+		host = h
+	}
+	if net.ParseIP(strings.Trim(host, "[]")) != nil {
+		return nil
+	}
+	if err := validateHostname(strings.ToLower(host)); err != nil {
+		return errors.Wrapf(err, "invalid endpoint %q", endpoint)
+	}
+	return nil
+}
+
+// validateHostname checks a lower case RFC 1123 DNS name.
+func validateHostname(name string) error {
+	if name == "" {
+		return errors.New("name is empty")
+	}
+	if len(name) > 253 {
+		return errors.New("name is longer than 253 characters")
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > 63 || !dnsLabelRegexp.MatchString(label) {
+			return errors.Errorf("label %q must consist of lower case letters, digits and '-', and start and end with a letter or digit", label)
+		}
+	}
+	return nil
+}
+
+// answerString returns the text of an answer.
+func answerString(answer interface{}) string {
+	switch a := answer.(type) {
+	case string:
+		return a
+	case core.OptionAnswer:
+		return a.Value
+	case nil:
+		return ""
+	}
+	return fmt.Sprintf("%v", answer)
+}
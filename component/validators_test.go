@@ -0,0 +1,114 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePrompt returns the given answers in order, recording the errors shown.
+type fakePrompt struct {
+	answers []interface{}
+	errors  []string
+}
+
+func (f *fakePrompt) Prompt(_ *survey.PromptConfig) (interface{}, error) {
+	answer := f.answers[0]
+	f.answers = f.answers[1:]
+	return answer, nil
+}
+
+func (f *fakePrompt) Cleanup(_ *survey.PromptConfig, _ interface{}) error {
+	return nil
+}
+
+func (f *fakePrompt) Error(_ *survey.PromptConfig, err error) error {
+	f.errors = append(f.errors, err.Error())
+	return nil
+}
+
+func TestAskOneValidatesTransformedAnswer(t *testing.T) {
+	prompt := &fakePrompt{answers: []interface{}{"   ", " bad name ", " My-Context "}}
+
+	var response string
+	err := askOne("", prompt, &response, []PromptOpt{
+		WithTransformer(strings.TrimSpace),
+		WithValidator(Required, ValidContextName),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "My-Context", response)
+	require.Equal(t, []string{
+		"a value is required",
+		`invalid context name "bad name", it must start and end with a letter or digit, and only contain letters, digits, '-', '_' and '.'`,
+	}, prompt.errors)
+}
+
+func TestRequired(t *testing.T) {
+	require.EqualError(t, Required(""), "a value is required")
+	require.EqualError(t, Required(" \t"), "a value is required")
+	require.EqualError(t, Required(nil), "a value is required")
+	require.EqualError(t, Required([]core.OptionAnswer{}), "select at least one option")
+	require.NoError(t, Required("value"))
+	require.NoError(t, Required(core.OptionAnswer{Value: "one"}))
+	require.NoError(t, Required([]core.OptionAnswer{{Value: "one"}}))
+}
+
+func TestStringValidators(t *testing.T) {
+	semver := MatchesRegexp(regexp.MustCompile(`^v\d+\.\d+\.\d+$`), "a semantic version")
+	require.NoError(t, semver("v1.2.3"))
+	require.EqualError(t, semver("1.2"), "must be a semantic version")
+
+	require.NoError(t, MinLength(3)("abc"))
+	require.EqualError(t, MinLength(3)("ab"), "must be at least 3 characters long")
+	require.NoError(t, MaxLength(3)("äöü"))
+	require.EqualError(t, MaxLength(3)("abcd"), "must be at most 3 characters long")
+
+	require.NoError(t, NumberInRange(1, 10)("10"))
+	require.NoError(t, NumberInRange(1, 10)(core.OptionAnswer{Value: "2.5"}))
+	require.EqualError(t, NumberInRange(1, 10)("11"), "must be between 1 and 10")
+	require.EqualError(t, NumberInRange(1, 10)("ten"), `"ten" is not a number`)
+}
+
+func TestValidContextName(t *testing.T) {
+	for _, name := range []string{"tkg-mgmt", "my_context.1", "A"} {
+		require.NoError(t, ValidContextName(name), name)
+	}
+	for _, name := range []string{"", "-ctx", "ctx-", "my context", "ctx/1", strings.Repeat("a", 64)} {
+		require.Error(t, ValidContextName(name), name)
+	}
+}
+
+func TestValidDNSName(t *testing.T) {
+	for _, name := range []string{"cluster", "my-cluster.example.com", "a1"} {
+		require.NoError(t, ValidDNSName(name), name)
+	}
+	for _, name := range []string{"", "My-Cluster", "cluster-", "a..b", "under_score", strings.Repeat("a", 64)} {
+		require.Error(t, ValidDNSName(name), name)
+	}
+}
+
+func TestValidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{
+		"https://api.example.com",
+		"http://localhost:8080/path",
+		"api.example.com:443",
+		"API.example.com",
+		"10.0.0.1",
+		"https://[::1]:6443",
+	} {
+		require.NoError(t, ValidEndpoint(endpoint), endpoint)
+	}
+
+	require.EqualError(t, ValidEndpoint(""), "an endpoint is required")
+	require.EqualError(t, ValidEndpoint("ftp://example.com"), `invalid endpoint "ftp://example.com", the scheme must be http or https`)
+	require.EqualError(t, ValidEndpoint("example.com:99999"), `invalid endpoint "example.com:99999", invalid port "99999"`)
+	require.Error(t, ValidEndpoint("https://exa mple.com"))
+	require.Error(t, ValidEndpoint("not_a_host"))
+}
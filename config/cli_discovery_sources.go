@@ -4,9 +4,9 @@
 package config
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
@@ -15,8 +15,13 @@ import (
 
 // GetCLIDiscoverySources retrieves cli discovery sources
 func GetCLIDiscoverySources() ([]configtypes.PluginDiscovery, error) {
+	return DefaultClient().GetCLIDiscoverySources()
+}
+
+// GetCLIDiscoverySources retrieves cli discovery sources
+func (client *Client) GetCLIDiscoverySources() ([]configtypes.PluginDiscovery, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +31,13 @@ func GetCLIDiscoverySources() ([]configtypes.PluginDiscovery, error) {
 
 // GetCLIDiscoverySource retrieves cli discovery source by name assuming that there should only be one source with the name, returns the first match
 func GetCLIDiscoverySource(name string) (*configtypes.PluginDiscovery, error) {
+	return DefaultClient().GetCLIDiscoverySource(name)
+}
+
+// GetCLIDiscoverySource retrieves cli discovery source by name assuming that there should only be one source with the name, returns the first match
+func (client *Client) GetCLIDiscoverySource(name string) (*configtypes.PluginDiscovery, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -37,23 +47,30 @@ func GetCLIDiscoverySource(name string) (*configtypes.PluginDiscovery, error) {
 
 // SetCLIDiscoverySources Add/Update array of cli discovery sources to the yaml node
 func SetCLIDiscoverySources(discoverySources []configtypes.PluginDiscovery) (err error) {
+	return DefaultClient().SetCLIDiscoverySources(discoverySources)
+}
+
+// SetCLIDiscoverySources Add/Update array of cli discovery sources to the yaml node
+func (client *Client) SetCLIDiscoverySources(discoverySources []configtypes.PluginDiscovery) (err error) {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err = client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
 
 	// Loop through each discovery source and add or update existing node
 	for _, discoverySource := range discoverySources {
-		persist, err := setCLIDiscoverySource(node, discoverySource)
+		persist, err := client.setCLIDiscoverySource(node, discoverySource)
 		if err != nil {
 			return err
 		}
 		// Persist the config node to the file
 		if persist {
-			err = persistConfig(node)
+			err = client.persistConfig(node)
 			if err != nil {
 				return err
 			}
@@ -64,23 +81,30 @@ func SetCLIDiscoverySources(discoverySources []configtypes.PluginDiscovery) (err
 
 // SetCLIDiscoverySource add or update a cli discoverySource
 func SetCLIDiscoverySource(discoverySource configtypes.PluginDiscovery) (err error) {
+	return DefaultClient().SetCLIDiscoverySource(discoverySource)
+}
+
+// SetCLIDiscoverySource add or update a cli discoverySource
+func (client *Client) SetCLIDiscoverySource(discoverySource configtypes.PluginDiscovery) (err error) {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err = client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
 
 	// Add/Update cli discovery source in the yaml node
-	persist, err := setCLIDiscoverySource(node, discoverySource)
+	persist, err := client.setCLIDiscoverySource(node, discoverySource)
 	if err != nil {
 		return err
 	}
 
 	// Persist the config node to the file
 	if persist {
-		return persistConfig(node)
+		return client.persistConfig(node)
 	}
 
 	return err
@@ -88,10 +112,17 @@ func SetCLIDiscoverySource(discoverySource configtypes.PluginDiscovery) (err err
 
 // DeleteCLIDiscoverySource delete cli discoverySource by name
 func DeleteCLIDiscoverySource(name string) error {
+	return DefaultClient().DeleteCLIDiscoverySource(name)
+}
+
+// DeleteCLIDiscoverySource delete cli discoverySource by name
+func (client *Client) DeleteCLIDiscoverySource(name string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -103,7 +134,7 @@ func DeleteCLIDiscoverySource(name string) error {
 	}
 
 	// Persist the config node to the file
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 func getCLIDiscoverySources(node *yaml.Node) ([]configtypes.PluginDiscovery, error) {
@@ -114,7 +145,7 @@ func getCLIDiscoverySources(node *yaml.Node) ([]configtypes.PluginDiscovery, err
 	if cfg.ClientOptions != nil && cfg.ClientOptions.CLI != nil && cfg.ClientOptions.CLI.DiscoverySources != nil {
 		return cfg.ClientOptions.CLI.DiscoverySources, nil
 	}
-	return nil, errors.New("cli discovery sources not found")
+	return nil, notFound(ErrCLIDiscoverySourceNotFound, "", "cli discovery sources not found")
 }
 
 func getCLIDiscoverySource(node *yaml.Node, name string) (*configtypes.PluginDiscovery, error) {
@@ -130,13 +161,13 @@ func getCLIDiscoverySource(node *yaml.Node, name string) (*configtypes.PluginDis
 			}
 		}
 	}
-	return nil, errors.New("cli discovery source not found")
+	return nil, notFound(ErrCLIDiscoverySourceNotFound, name, "cli discovery source not found")
 }
 
 // setCLIDiscoverySources Add/Update array of cli discovery sources to the yaml node
-func setCLIDiscoverySources(node *yaml.Node, discoverySources []configtypes.PluginDiscovery) (err error) {
+func (client *Client) setCLIDiscoverySources(node *yaml.Node, discoverySources []configtypes.PluginDiscovery) (err error) {
 	for _, discoverySource := range discoverySources {
-		_, err = setCLIDiscoverySource(node, discoverySource)
+		_, err = client.setCLIDiscoverySource(node, discoverySource)
 		if err != nil {
 			return err
 		}
@@ -145,9 +176,9 @@ func setCLIDiscoverySources(node *yaml.Node, discoverySources []configtypes.Plug
 }
 
 // setCLIDiscoverySource Add/Update cli discovery source in the yaml node
-func setCLIDiscoverySource(node *yaml.Node, discoverySource configtypes.PluginDiscovery) (persist bool, err error) {
+func (client *Client) setCLIDiscoverySource(node *yaml.Node, discoverySource configtypes.PluginDiscovery) (persist bool, err error) {
 	// Retrieve the patch strategies from config metadata
-	patchStrategies, err := GetConfigMetadataPatchStrategy()
+	patchStrategies, err := client.GetConfigMetadataPatchStrategy()
 	if err != nil {
 		patchStrategies = make(map[string]string)
 	}
@@ -731,3 +731,16 @@ func TestSetCLIDiscoverySourceMultiTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestSetCLIDiscoverySourceWithoutTypeOrName(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(nil)))
+
+	for _, discoverySource := range []configtypes.PluginDiscovery{
+		{},
+		{OCI: &configtypes.OCIDiscovery{Image: "test-image:latest"}},
+	} {
+		err := client.SetCLIDiscoverySource(discoverySource)
+		assert.EqualError(t, err, "invalid discovery source: the discovery source type and name are required")
+		assert.NotErrorIs(t, err, ErrNotFound)
+	}
+}
@@ -4,7 +4,8 @@
 package config
 
 import (
-	"github.com/pkg/errors"
+	"context"
+
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
@@ -12,8 +13,13 @@ import (
 
 // GetEdition retrieves ClientOptions Edition
 func GetEdition() (string, error) {
+	return DefaultClient().GetEdition()
+}
+
+// GetEdition retrieves ClientOptions Edition
+func (client *Client) GetEdition() (string, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return "", err
 	}
@@ -22,10 +28,17 @@ func GetEdition() (string, error) {
 
 // SetEdition adds or updates edition value
 func SetEdition(val string) (err error) {
+	return DefaultClient().SetEdition(val)
+}
+
+// SetEdition adds or updates edition value
+func (client *Client) SetEdition(val string) (err error) {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err = client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -35,7 +48,7 @@ func SetEdition(val string) (err error) {
 
 	// Persist the config node to the file
 	if persist {
-		return persistConfig(node)
+		return client.persistConfig(node)
 	}
 	return err
 }
@@ -58,7 +71,7 @@ func getEdition(node *yaml.Node) (string, error) {
 		//nolint:staticcheck
 		return string(cfg.ClientOptions.CLI.Edition), nil
 	}
-	return "", errors.New("edition not found")
+	return "", notFound(ErrEditionNotFound, "", "edition not found")
 }
 
 func setUnstableVersionSelector(node *yaml.Node, name string) (persist bool) {
@@ -4,7 +4,8 @@
 package config
 
 import (
-	"github.com/pkg/errors"
+	"context"
+
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
@@ -12,8 +13,13 @@ import (
 
 // GetCEIPOptIn retrieves ClientOptions ceipOptIn
 func GetCEIPOptIn() (string, error) {
+	return DefaultClient().GetCEIPOptIn()
+}
+
+// GetCEIPOptIn retrieves ClientOptions ceipOptIn
+func (client *Client) GetCEIPOptIn() (string, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return "", err
 	}
@@ -22,10 +28,17 @@ func GetCEIPOptIn() (string, error) {
 
 // SetCEIPOptIn adds or updates ceipOptIn value
 func SetCEIPOptIn(val string) (err error) {
+	return DefaultClient().SetCEIPOptIn(val)
+}
+
+// SetCEIPOptIn adds or updates ceipOptIn value
+func (client *Client) SetCEIPOptIn(val string) (err error) {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err = client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -35,7 +48,7 @@ func SetCEIPOptIn(val string) (err error) {
 
 	// Persist the config node to the file
 	if persist {
-		return persistConfig(node)
+		return client.persistConfig(node)
 	}
 	return err
 }
@@ -57,7 +70,7 @@ func getCEIPOptIn(node *yaml.Node) (string, error) {
 	if cfg != nil && cfg.CoreCliOptions != nil {
 		return cfg.CoreCliOptions.CEIPOptIn, nil
 	}
-	return "", errors.New("ceipOptIn not found")
+	return "", notFound(ErrCEIPOptInNotFound, "", "ceipOptIn not found")
 }
 
 // getNGCLIOptionsChildNode parses the yaml node and returns the matched node based on configOptions
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -15,8 +16,13 @@ import (
 
 // GetCLIRepositories retrieves cli repositories
 func GetCLIRepositories() ([]configtypes.PluginRepository, error) {
+	return DefaultClient().GetCLIRepositories()
+}
+
+// GetCLIRepositories retrieves cli repositories
+func (client *Client) GetCLIRepositories() ([]configtypes.PluginRepository, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +32,13 @@ func GetCLIRepositories() ([]configtypes.PluginRepository, error) {
 
 // GetCLIRepository retrieves cli repository by name
 func GetCLIRepository(name string) (*configtypes.PluginRepository, error) {
+	return DefaultClient().GetCLIRepository(name)
+}
+
+// GetCLIRepository retrieves cli repository by name
+func (client *Client) GetCLIRepository(name string) (*configtypes.PluginRepository, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -37,23 +48,30 @@ func GetCLIRepository(name string) (*configtypes.PluginRepository, error) {
 
 // SetCLIRepository add or update a repository
 func SetCLIRepository(repository configtypes.PluginRepository) (err error) {
+	return DefaultClient().SetCLIRepository(repository)
+}
+
+// SetCLIRepository add or update a repository
+func (client *Client) SetCLIRepository(repository configtypes.PluginRepository) (err error) {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err = client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
 
 	// Add or update cli repository in the yaml node
-	persist, err := setCLIRepository(node, repository)
+	persist, err := client.setCLIRepository(node, repository)
 	if err != nil {
 		return err
 	}
 
 	// Persist the config node to the file
 	if persist {
-		err = persistConfig(node)
+		err = client.persistConfig(node)
 		if err != nil {
 			return err
 		}
@@ -64,10 +82,17 @@ func SetCLIRepository(repository configtypes.PluginRepository) (err error) {
 
 // DeleteCLIRepository delete a cli repository by name
 func DeleteCLIRepository(name string) error {
+	return DefaultClient().DeleteCLIRepository(name)
+}
+
+// DeleteCLIRepository delete a cli repository by name
+func (client *Client) DeleteCLIRepository(name string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -79,7 +104,7 @@ func DeleteCLIRepository(name string) error {
 	}
 
 	// Persist the config node to the file
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 func getCLIRepositories(node *yaml.Node) ([]configtypes.PluginRepository, error) {
@@ -90,7 +115,7 @@ func getCLIRepositories(node *yaml.Node) ([]configtypes.PluginRepository, error)
 	if cfg.ClientOptions != nil && cfg.ClientOptions.CLI != nil && cfg.ClientOptions.CLI.Repositories != nil {
 		return cfg.ClientOptions.CLI.Repositories, nil
 	}
-	return nil, errors.New("cli repositories not found")
+	return nil, notFound(ErrCLIRepositoryNotFound, "", "cli repositories not found")
 }
 
 func getCLIRepository(node *yaml.Node, name string) (*configtypes.PluginRepository, error) {
@@ -106,12 +131,12 @@ func getCLIRepository(node *yaml.Node, name string) (*configtypes.PluginReposito
 			}
 		}
 	}
-	return nil, errors.New("cli repository not found")
+	return nil, notFound(ErrCLIRepositoryNotFound, name, "cli repository not found")
 }
 
-func setCLIRepositories(node *yaml.Node, repos []configtypes.PluginRepository) (err error) {
+func (client *Client) setCLIRepositories(node *yaml.Node, repos []configtypes.PluginRepository) (err error) {
 	for _, repository := range repos {
-		_, err = setCLIRepository(node, repository)
+		_, err = client.setCLIRepository(node, repository)
 		if err != nil {
 			return err
 		}
@@ -119,9 +144,9 @@ func setCLIRepositories(node *yaml.Node, repos []configtypes.PluginRepository) (
 	return err
 }
 
-func setCLIRepository(node *yaml.Node, repository configtypes.PluginRepository) (persist bool, err error) {
+func (client *Client) setCLIRepository(node *yaml.Node, repository configtypes.PluginRepository) (persist bool, err error) {
 	// Retrieve the patch strategies from config metadata
-	patchStrategies, err := GetConfigMetadataPatchStrategy()
+	patchStrategies, err := client.GetConfigMetadataPatchStrategy()
 	if err != nil {
 		patchStrategies = make(map[string]string)
 	}
@@ -185,7 +210,7 @@ func setRepository(repositoriesNode *yaml.Node, repository configtypes.PluginRep
 
 	repositoryType, repositoryName := getRepositoryTypeAndName(repository)
 	if repositoryType == "" || repositoryName == "" {
-		return persist, errors.New("not found")
+		return persist, errors.New("invalid repository: the repository type and name are required")
 	}
 
 	// loop through the repositories seqence node
@@ -89,3 +89,16 @@ func TestSetGetRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestSetRepositoryWithoutTypeOrName(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(nil)))
+
+	for _, repository := range []configtypes.PluginRepository{
+		{},
+		{GCPPluginRepository: &configtypes.GCPPluginRepository{BucketName: "bucket"}},
+	} {
+		err := client.SetCLIRepository(repository)
+		assert.EqualError(t, err, "invalid repository: the repository type and name are required")
+		assert.NotErrorIs(t, err, ErrNotFound)
+	}
+}
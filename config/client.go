@@ -0,0 +1,138 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Client reads and writes the tanzu configuration kept in its storage backend,
+// with its own locks. Each of the package-level config APIs is a wrapper of the
+// matching method of the default client.
+type Client struct {
+	storage     Storage
+	lockTimeout time.Duration
+	snapshotDir string
+}
+
+// ClientOption is an option of the config client
+type ClientOption func(*Client)
+
+// WithStorage sets the storage backend of the client
+func WithStorage(storage Storage) ClientOption {
+	return func(client *Client) {
+		client.storage = storage
+	}
+}
+
+// WithLocalDir stores the config files of the client in the specified directory,
+// regardless of the environment variables overriding the config paths. The config
+// is not written to the legacy config directory.
+func WithLocalDir(dir string) ClientOption {
+	return func(client *Client) {
+		client.storage = newFilesystemStorageInDir(dir)
+	}
+}
+
+// WithLockTimeout sets the maximum time waiting on the locks of the config
+// files. By default, the lock of config.yaml is waited on for DefaultLockTimeout,
+// the lock of config-ng.yaml for DefaultConfigNextGenLockTimeout, and the lock
+// of the config metadata file for DefaultMetadataLockTimeout.
+func WithLockTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.lockTimeout = timeout
+	}
+}
+
+// WithSnapshotDir sets the directory the config snapshots of the client are
+// stored in, the snapshots directory next to the config file by default
+func WithSnapshotDir(dir string) ClientOption {
+	return func(client *Client) {
+		client.snapshotDir = dir
+	}
+}
+
+// NewClient returns a config client, storing the config files in the local tanzu
+// directory or at the paths set with the environment variables by default
+func NewClient(opts ...ClientOption) *Client {
+	client := &Client{}
+	for _, opt := range opts {
+		opt(client)
+	}
+	if client.storage == nil {
+		client.storage = NewFilesystemStorage()
+	}
+	return client
+}
+
+// Storage returns the storage backend of the client
+func (client *Client) Storage() Storage {
+	return client.storage
+}
+
+// ClientConfigPath returns the path of the config file of the client
+func (client *Client) ClientConfigPath() (path string, err error) {
+	return client.filePath(ClientConfigFile)
+}
+
+// ClientConfigNextGenPath returns the path of the config-ng file of the client
+func (client *Client) ClientConfigNextGenPath() (path string, err error) {
+	return client.filePath(ClientConfigNextGenFile)
+}
+
+// CfgMetadataFilePath returns the path of the config metadata file of the client
+func (client *Client) CfgMetadataFilePath() (path string, err error) {
+	return client.filePath(MetadataFile)
+}
+
+// location returns the path of the config file, or else its name in the storage
+func (client *Client) location(file ConfigFile) string {
+	if path, err := client.filePath(file); err == nil {
+		return path
+	}
+	return string(file)
+}
+
+func (client *Client) filePath(file ConfigFile) (string, error) {
+	storage, ok := client.storage.(*filesystemStorage)
+	if !ok {
+		return "", errors.New("the config is not stored in the filesystem")
+	}
+	return storage.path(file)
+}
+
+var (
+	defaultClientMutex sync.RWMutex
+	defaultClient      = NewClient()
+)
+
+// DefaultClient returns the client used by the package-level config APIs
+func DefaultClient() *Client {
+	defaultClientMutex.RLock()
+	defer defaultClientMutex.RUnlock()
+	return defaultClient
+}
+
+// SetDefaultClient sets the client used by the package-level config APIs.
+// It is meant to be called once, before using the config APIs, and not while
+// holding a config lock.
+func SetDefaultClient(client *Client) {
+	defaultClientMutex.Lock()
+	defer defaultClientMutex.Unlock()
+	defaultClient = client
+}
+
+// SetStorage sets the storage backend of the package-level config APIs, which
+// is the filesystem by default. It replaces the default client.
+func SetStorage(storage Storage) {
+	SetDefaultClient(NewClient(WithStorage(storage)))
+}
+
+// GetStorage returns the storage backend of the package-level config APIs
+func GetStorage() Storage {
+	return DefaultClient().Storage()
+}
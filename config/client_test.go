@@ -0,0 +1,93 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestClientsWithSeparateConfigRoots(t *testing.T) {
+	source, target := t.TempDir(), t.TempDir()
+	t.Setenv(EnvConfigKey, filepath.Join(t.TempDir(), "ignored.yaml"))
+	sourceClient := NewClient(WithLocalDir(source))
+	targetClient := NewClient(WithLocalDir(target))
+
+	ctx := &configtypes.Context{
+		Name:   "test-ctx",
+		Target: configtypes.TargetTMC,
+		GlobalOpts: &configtypes.GlobalServer{
+			Endpoint: "test-endpoint",
+		},
+	}
+	assert.NoError(t, sourceClient.SetContext(ctx, true))
+	assert.NoError(t, sourceClient.SetEnv("key", "source"))
+
+	// Migrate the contexts and envs of the source config to the target config
+	sourceCfg, err := sourceClient.GetClientConfig()
+	assert.NoError(t, err)
+	for _, c := range sourceCfg.KnownContexts {
+		assert.NoError(t, targetClient.SetContext(c, false))
+	}
+	assert.NoError(t, targetClient.SetEnv("key", "target"))
+
+	migrated, err := targetClient.GetContext("test-ctx")
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, migrated)
+	_, err = targetClient.GetCurrentContext(configtypes.TargetTMC)
+	assert.Error(t, err)
+
+	sourceEnv, err := sourceClient.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "source", sourceEnv)
+	targetEnv, err := targetClient.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "target", targetEnv)
+
+	path, err := targetClient.ClientConfigNextGenPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(target, CfgNextGenName), path)
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(target, LocalTanzuFileLock))
+	assert.NoError(t, err)
+	_, err = os.Stat(os.Getenv(EnvConfigKey))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestClientLocksAreIndependent(t *testing.T) {
+	first := NewClient(WithStorage(NewMemoryStorage(nil)))
+	second := NewClient(WithStorage(NewMemoryStorage(nil)))
+
+	first.AcquireTanzuConfigLock()
+	defer first.ReleaseTanzuConfigLock()
+
+	// The lock of the first client does not block the second client
+	assert.NoError(t, second.SetFeature("global", "feature", "true"))
+	enabled, err := second.IsFeatureEnabled("global", "feature")
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+}
+
+func TestDefaultClient(t *testing.T) {
+	previous := DefaultClient()
+	defer SetDefaultClient(previous)
+
+	client := NewClient(WithStorage(NewMemoryStorage(nil)))
+	SetDefaultClient(client)
+	assert.Equal(t, client.Storage(), GetStorage())
+
+	assert.NoError(t, SetEnv("key", "val"))
+	val, err := client.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "val", val)
+
+	_, err = client.ClientConfigPath()
+	assert.EqualError(t, err, "the config is not stored in the filesystem")
+}
@@ -9,7 +9,7 @@
 package config
 
 import (
-	"os"
+	"context"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
@@ -18,61 +18,70 @@ import (
 )
 
 // getClientConfigNode retrieves the multi config from the local directory with file lock
-func getClientConfigNode() (*yaml.Node, error) {
-	useUnifiedConfig, err := UseUnifiedConfig()
+func (client *Client) getClientConfigNode() (*yaml.Node, error) {
+	useUnifiedConfig, err := client.UseUnifiedConfig()
 	if err != nil {
 		useUnifiedConfig = false
 	}
 
 	if useUnifiedConfig {
-		return getClientConfigNextGenNode()
+		return client.getClientConfigNextGenNode()
 	}
-	return getMultiConfig()
+	return client.getMultiConfig()
 }
 
 // getClientConfigNodeNoLock retrieves the multi config from the local directory without acquiring the lock
-func getClientConfigNodeNoLock() (*yaml.Node, error) {
+func (client *Client) getClientConfigNodeNoLock() (*yaml.Node, error) {
 	// Check config migration feature flag
-	useUnifiedConfig, err := UseUnifiedConfig()
+	useUnifiedConfig, err := client.UseUnifiedConfig()
 	if err != nil {
 		useUnifiedConfig = false
 	}
 
 	if useUnifiedConfig {
-		return getClientConfigNextGenNodeNoLock()
+		return client.getClientConfigNextGenNodeNoLock()
 	}
-	return getMultiConfigNoLock()
+	return client.getMultiConfigNoLock()
+}
+
+// getClientConfigNodeFor retrieves the config-ng.yaml config if useUnifiedConfig
+// is set, or else the multi config, without acquiring the lock
+func (client *Client) getClientConfigNodeFor(useUnifiedConfig bool) (*yaml.Node, error) {
+	if useUnifiedConfig {
+		return client.getClientConfigNextGenNodeNoLock()
+	}
+	return client.getMultiConfigNoLock()
 }
 
 // getClientConfig retrieves the config from the local directory with file lock
-func getClientConfig() (*yaml.Node, error) {
+func (client *Client) getClientConfig() (*yaml.Node, error) {
 	// Acquire tanzu config lock
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	return getClientConfigNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return nil, err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	return client.getClientConfigNoLock()
 }
 
 // getClientConfigNoLock retrieves the config from the local directory without acquiring the lock
-func getClientConfigNoLock() (*yaml.Node, error) {
-	cfgPath, err := ClientConfigPath()
+func (client *Client) getClientConfigNoLock() (*yaml.Node, error) {
+	bytes, err := client.storage.Read(ClientConfigFile)
 	if err != nil {
-		return nil, errors.Wrap(err, "getClientConfigNodeNoLock: failed getting client config path")
+		return nil, errors.Wrap(err, "getClientConfigNodeNoLock: failed reading client config")
 	}
-	bytes, err := os.ReadFile(cfgPath)
-	if err != nil || len(bytes) == 0 {
+	if len(bytes) == 0 {
 		node, err := newClientConfigNode()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create new client config")
 		}
 		return node, nil
 	}
-	var node yaml.Node
-	err = yaml.Unmarshal(bytes, &node)
+	node, err := client.unmarshalNode(ClientConfigFile, bytes)
 	if err != nil {
-		return nil, errors.Wrap(err, "getClientConfigNodeNoLock: failed to construct struct from config data")
+		return nil, configCorrupt(client.location(ClientConfigFile), errors.Wrap(err, "getClientConfigNodeNoLock: failed to construct struct from config data"))
 	}
 	node.Content[0].Style = 0
-	return &node, nil
+	return node, nil
 }
 
 // newClientConfigNode create and return new client config node
@@ -87,10 +96,6 @@ func newClientConfigNode() (*yaml.Node, error) {
 }
 
 // persistClientConfig write to config.yaml
-func persistClientConfig(node *yaml.Node) error {
-	path, err := ClientConfigPath()
-	if err != nil {
-		return errors.Wrap(err, "could not find config path")
-	}
-	return persistNode(node, WithCfgPath(path))
+func (client *Client) persistClientConfig(node *yaml.Node) error {
+	return client.persistNode(node, ClientConfigFile)
 }
@@ -16,7 +16,7 @@ import (
 
 func TestClientConfigNodeUpdateInParallel(t *testing.T) {
 	addServer := func(mcName string) error {
-		_, err := getClientConfigNode()
+		_, err := DefaultClient().getClientConfigNode()
 		if err != nil {
 			return err
 		}
@@ -33,7 +33,7 @@ func TestClientConfigNodeUpdateInParallel(t *testing.T) {
 		if err != nil {
 			return err
 		}
-		_, err = getClientConfigNode()
+		_, err = DefaultClient().getClientConfigNode()
 		return err
 	}
 	// Run the parallel tests of reading and updating the configuration file
@@ -58,7 +58,7 @@ func TestClientConfigNodeUpdateInParallel(t *testing.T) {
 			}
 			_ = group.Wait()
 			// Make sure that the configuration file is not corrupted
-			node, err := getClientConfigNode()
+			node, err := DefaultClient().getClientConfigNode()
 			assert.Nil(t, err)
 			// Make sure all expected servers are added to the knownServers list
 			assert.Equal(t, parallelExecutionCounter, len(node.Content[0].Content[5].Content))
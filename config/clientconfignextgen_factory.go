@@ -9,47 +9,43 @@
 package config
 
 import (
-	"os"
+	"context"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
 
 // getClientConfigNextGenNode retrieves the config from the local directory with file lock
-func getClientConfigNextGenNode() (*yaml.Node, error) {
+func (client *Client) getClientConfigNextGenNode() (*yaml.Node, error) {
 	// Acquire tanzu config v2 lock
-	AcquireTanzuConfigNextGenLock()
-	defer ReleaseTanzuConfigNextGenLock()
-	return getClientConfigNextGenNodeNoLock()
+	if err := client.AcquireTanzuConfigNextGenLockContext(context.Background()); err != nil {
+		return nil, err
+	}
+	defer client.ReleaseTanzuConfigNextGenLock()
+	return client.getClientConfigNextGenNodeNoLock()
 }
 
 // getClientConfigNextGenNodeNoLock retrieves the config from the local directory without acquiring the lock
-func getClientConfigNextGenNodeNoLock() (*yaml.Node, error) {
-	cfgPath, err := ClientConfigNextGenPath()
+func (client *Client) getClientConfigNextGenNodeNoLock() (*yaml.Node, error) {
+	bytes, err := client.storage.Read(ClientConfigNextGenFile)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed getting client config path")
+		return nil, errors.Wrap(err, "failed reading client config ng")
 	}
-	bytes, err := os.ReadFile(cfgPath)
-	if err != nil || len(bytes) == 0 {
+	if len(bytes) == 0 {
 		node, err := newClientConfigNode()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create new client config ng")
 		}
 		return node, nil
 	}
-	var node yaml.Node
-	err = yaml.Unmarshal(bytes, &node)
+	node, err := client.unmarshalNode(ClientConfigNextGenFile, bytes)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to construct struct from config ng data")
+		return nil, configCorrupt(client.location(ClientConfigNextGenFile), errors.Wrap(err, "failed to construct struct from config ng data"))
 	}
 	node.Content[0].Style = 0
-	return &node, nil
+	return node, nil
 }
 
-func persistClientConfigNextGen(node *yaml.Node) error {
-	path, err := ClientConfigNextGenPath()
-	if err != nil {
-		return errors.Wrap(err, "could not find config ng path")
-	}
-	return persistNode(node, WithCfgPath(path))
+func (client *Client) persistClientConfigNextGen(node *yaml.Node) error {
+	return client.persistNode(node, ClientConfigNextGenFile)
 }
@@ -23,7 +23,7 @@ func TestGetClientConfigNextGenNode(t *testing.T) {
 	}()
 
 	//Action
-	node, err := getClientConfigNextGenNode()
+	node, err := DefaultClient().getClientConfigNextGenNode()
 
 	//Assertions
 	assert.NoError(t, err)
@@ -36,7 +36,7 @@ func TestGetClientConfigNextGenNode(t *testing.T) {
 
 func TestClientConfigNextGenNodeUpdateInParallel(t *testing.T) {
 	addContext := func(mcName string) error {
-		_, err := getClientConfigNextGenNode()
+		_, err := DefaultClient().getClientConfigNextGenNode()
 		if err != nil {
 			return err
 		}
@@ -63,7 +63,7 @@ func TestClientConfigNextGenNodeUpdateInParallel(t *testing.T) {
 		if err != nil {
 			return err
 		}
-		_, err = getClientConfigNextGenNode()
+		_, err = DefaultClient().getClientConfigNextGenNode()
 		return err
 	}
 	// Run the parallel tests of reading and updating the configuration file
@@ -88,7 +88,7 @@ func TestClientConfigNextGenNodeUpdateInParallel(t *testing.T) {
 			}
 			_ = group.Wait()
 			// Make sure that the configuration file is not corrupted
-			node, err := getClientConfigNextGenNode()
+			node, err := DefaultClient().getClientConfigNextGenNode()
 			assert.Nil(t, err)
 			// Make sure all expected servers are added to the knownServers list
 			assert.Equal(t, parallelExecutionCounter, len(node.Content[0].Content[1].Content))
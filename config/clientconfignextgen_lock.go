@@ -5,12 +5,9 @@
 package config
 
 import (
+	"context"
 	"fmt"
-	"path/filepath"
-	"sync"
 	"time"
-
-	"github.com/juju/fslock"
 )
 
 const (
@@ -19,49 +16,48 @@ const (
 	DefaultConfigNextGenLockTimeout = 10 * time.Minute
 )
 
-var cfgNextGenLockFile string
-
-// cfgNextGenLock used as a static lock variable that stores fslock
-// This is used for interprocess locking of the config file
-var cfgNextGenLock *fslock.Lock
-
-// cfgNextGenMutex is used to handle the locking behavior between concurrent calls
-// within the existing process trying to acquire the lock
-var cfgNextGenMutex sync.Mutex
-
 // AcquireTanzuConfigNextGenLock tries to acquire lock to update tanzu config file with timeout
 func AcquireTanzuConfigNextGenLock() {
-	var err error
-
-	if cfgNextGenLockFile == "" {
-		path, err := ClientConfigNextGenPath()
-		if err != nil {
-			panic(fmt.Sprintf("cannot get config path while acquiring lock on tanzu config file, reason: %v", err))
-		}
-		cfgNextGenLockFile = filepath.Join(filepath.Dir(path), LocalTanzuConfigNextGenFileLock)
-	}
+	DefaultClient().AcquireTanzuConfigNextGenLock()
+}
 
-	// using fslock to handle interprocess locking
-	lock, err := getFileLockWithTimeOut(cfgNextGenLockFile, DefaultConfigNextGenLockTimeout)
-	if err != nil {
+// AcquireTanzuConfigNextGenLock tries to acquire lock to update tanzu config file with timeout
+func (client *Client) AcquireTanzuConfigNextGenLock() {
+	if err := client.AcquireTanzuConfigNextGenLockContext(context.Background()); err != nil {
 		panic(fmt.Sprintf("cannot acquire lock for tanzu config file, reason: %v", err))
 	}
+}
 
-	// Lock the mutex to prevent concurrent calls to acquire and configure the tanzuConfigLock
-	cfgNextGenMutex.Lock()
-	cfgNextGenLock = lock
+// AcquireTanzuConfigNextGenLockContext acquires the lock of the config-ng file, waiting
+// until the context is done or the lock timeout expires
+func AcquireTanzuConfigNextGenLockContext(ctx context.Context) error {
+	return DefaultClient().AcquireTanzuConfigNextGenLockContext(ctx)
+}
+
+// AcquireTanzuConfigNextGenLockContext acquires the lock of the config-ng file, waiting
+// until the context is done or the lock timeout expires
+func (client *Client) AcquireTanzuConfigNextGenLockContext(ctx context.Context) error {
+	return client.lock(ctx, ClientConfigNextGenFile)
 }
 
 // ReleaseTanzuConfigNextGenLock releases the lock if the tanzuConfigLock was acquired
 func ReleaseTanzuConfigNextGenLock() {
-	if cfgNextGenLock == nil {
-		return
-	}
-	if errUnlock := cfgNextGenLock.Unlock(); errUnlock != nil {
-		panic(fmt.Sprintf("cannot release lock for tanzu config file, reason: %v", errUnlock))
+	DefaultClient().ReleaseTanzuConfigNextGenLock()
+}
+
+// ReleaseTanzuConfigNextGenLock releases the lock if the tanzuConfigLock was acquired
+func (client *Client) ReleaseTanzuConfigNextGenLock() {
+	if err := client.ReleaseTanzuConfigNextGenLockE(); err != nil {
+		panic(fmt.Sprintf("cannot release lock for tanzu config file, reason: %v", err))
 	}
+}
+
+// ReleaseTanzuConfigNextGenLockE releases the lock of the config-ng file if acquired
+func ReleaseTanzuConfigNextGenLockE() error {
+	return DefaultClient().ReleaseTanzuConfigNextGenLockE()
+}
 
-	cfgNextGenLock = nil
-	// Unlock the mutex to allow other concurrent calls to acquire and configure the tanzuConfigLock
-	cfgNextGenMutex.Unlock()
+// ReleaseTanzuConfigNextGenLockE releases the lock of the config-ng file if acquired
+func (client *Client) ReleaseTanzuConfigNextGenLockE() error {
+	return client.storage.Unlock(ClientConfigNextGenFile)
 }
@@ -5,13 +5,14 @@
 package config
 
 import (
-	"os"
+	"context"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/collectionutils"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
 // LegacyConfigNodeKeys config nodes that goes to config.yaml
@@ -30,13 +31,13 @@ var DiscardedConfigNodeKeys = []string{
 }
 
 // getMultiConfig retrieves combined config.yaml and config-ng.yaml
-func getMultiConfig() (*yaml.Node, error) {
-	cfgNode, err := getClientConfig()
+func (client *Client) getMultiConfig() (*yaml.Node, error) {
+	cfgNode, err := client.getClientConfig()
 	if err != nil {
 		return cfgNode, err
 	}
 
-	cfgNextGenNode, err := getClientConfigNextGenNode()
+	cfgNextGenNode, err := client.getClientConfigNextGenNode()
 	if err != nil {
 		return cfgNextGenNode, err
 	}
@@ -45,13 +46,13 @@ func getMultiConfig() (*yaml.Node, error) {
 }
 
 // getMultiConfigNoLock retrieves combined config.yaml and config-ng.yaml
-func getMultiConfigNoLock() (*yaml.Node, error) {
-	cfgNode, err := getClientConfigNoLock()
+func (client *Client) getMultiConfigNoLock() (*yaml.Node, error) {
+	cfgNode, err := client.getClientConfigNoLock()
 	if err != nil {
 		return cfgNode, err
 	}
 
-	cfgNextGenNode, err := getClientConfigNextGenNodeNoLock()
+	cfgNextGenNode, err := client.getClientConfigNextGenNodeNoLock()
 	if err != nil {
 		return cfgNextGenNode, err
 	}
@@ -86,26 +87,31 @@ func makeMultiFileCfg(cfgNode, cfgNextGenNode *yaml.Node) (*yaml.Node, error) {
 }
 
 // persistConfig write the updated node data to config.yaml and config-ng.yaml based on cfgItems
-func persistConfig(node *yaml.Node) error {
+func (client *Client) persistConfig(node *yaml.Node) error {
 	// check to persist multi file or to config-ng yaml
-	useUnifiedConfig, err := UseUnifiedConfig()
+	useUnifiedConfig, err := client.useUnifiedConfig()
 	if err != nil {
-		useUnifiedConfig = false
+		return err
 	}
+	return client.persistConfigFor(node, useUnifiedConfig)
+}
 
+// persistConfigFor write the updated node data to config-ng.yaml if useUnifiedConfig
+// is set, or else to config.yaml and config-ng.yaml based on cfgItems
+func (client *Client) persistConfigFor(node *yaml.Node, useUnifiedConfig bool) error {
 	// If useUnifiedConfig is set to true write to config-ng.yaml
 	if useUnifiedConfig {
-		return persistClientConfigNextGen(node)
+		return client.persistClientConfigNextGen(node)
 	}
 
 	// config node from config.yaml
-	cfgNode, err := getClientConfigNoLock()
+	cfgNode, err := client.getClientConfigNoLock()
 	if err != nil {
 		return err
 	}
 
 	// config next gen node from config-ng.yaml
-	cfgNextGenNode, err := getClientConfigNextGenNodeNoLock()
+	cfgNextGenNode, err := client.getClientConfigNextGenNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -152,19 +158,19 @@ func persistConfig(node *yaml.Node) error {
 	}
 
 	// Store the non nextGenItem config data to config.yaml
-	err = persistClientConfig(cfgNode)
+	err = client.persistClientConfig(cfgNode)
 	if err != nil {
 		return err
 	}
 
 	// Store the nextGenItem config data to config-ng.yaml
-	err = persistClientConfigNextGen(cfgNextGenNode)
+	err = client.persistClientConfigNextGen(cfgNextGenNode)
 	if err != nil {
 		return err
 	}
 
 	// Store the config data to legacy client config file/location
-	err = persistLegacyClientConfig(cfgNode)
+	err = client.persistLegacyClientConfig(cfgNode)
 	if err != nil {
 		return err
 	}
@@ -172,32 +178,58 @@ func persistConfig(node *yaml.Node) error {
 	return nil
 }
 
-// persistNode stores/writes the yaml node to config path specified in CfgOpts
-func persistNode(node *yaml.Node, opts ...CfgOpts) error {
-	configurations := &CfgOptions{}
-	for _, opt := range opts {
-		opt(configurations)
-	}
-	cfgPathExists, err := fileExists(configurations.CfgPath)
-	if err != nil {
-		return errors.Wrap(err, "failed to check config path existence")
-	}
-	if !cfgPathExists {
-		localDir, err := LocalDir()
-		if err != nil {
-			return errors.Wrap(err, "could not find local tanzu dir for OS")
-		}
-		if err := os.MkdirAll(localDir, 0755); err != nil {
-			return errors.Wrap(err, "could not make local tanzu directory")
-		}
-	}
+// persistNode stores/writes the yaml node to the config file in the storage backend
+func (client *Client) persistNode(node *yaml.Node, file ConfigFile) error {
 	data, err := yaml.Marshal(node)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal nodeutils")
 	}
-	err = os.WriteFile(configurations.CfgPath, data, 0644)
+	return client.storage.Write(file, data)
+}
+
+// unmarshalNode parses the content of the config file, recovering the last
+// valid version of the file from its backup if the content cannot be parsed
+func (client *Client) unmarshalNode(file ConfigFile, data []byte) (*yaml.Node, error) {
+	var node yaml.Node
+	err := yaml.Unmarshal(data, &node)
+	if err == nil {
+		return &node, nil
+	}
+
+	backupStorage, ok := client.storage.(BackupStorage)
+	if !ok {
+		return nil, err
+	}
+	backup, backupErr := backupStorage.ReadBackup(file)
+	if backupErr != nil || !isYAMLDocument(backup) {
+		return nil, err
+	}
+	var backupNode yaml.Node
+	if backupErr = yaml.Unmarshal(backup, &backupNode); backupErr != nil {
+		return nil, err
+	}
+	log.Warningf("The config file %s cannot be parsed, using the backup of its last valid version instead: %v", client.location(file), err)
+	return &backupNode, nil
+}
+
+// readConfigFiles reads the content of the config files holding their locks,
+// omitting the files which do not exist
+func (client *Client) readConfigFiles(configFiles []ConfigFile) (map[ConfigFile]string, error) {
+	unlock, err := client.lockConfigFiles(context.Background())
 	if err != nil {
-		return errors.Wrap(err, "failed to write the config to file")
+		return nil, err
 	}
-	return nil
+	defer unlock()
+
+	files := make(map[ConfigFile]string, len(configFiles))
+	for _, file := range configFiles {
+		data, readErr := client.storage.Read(file)
+		if readErr != nil {
+			return nil, errors.Wrapf(readErr, "failed to read %s", client.location(file))
+		}
+		if data != nil {
+			files[file] = string(data)
+		}
+	}
+	return files, nil
 }
@@ -238,10 +238,10 @@ func TestGetClientConfigWithLockAndWithoutLock(t *testing.T) {
 	}()
 
 	//Actions
-	nodeWithLock, err := getClientConfigNode()
+	nodeWithLock, err := DefaultClient().getClientConfigNode()
 	assert.NoError(t, err)
 	//Actions
-	nodeWithoutLocK, err := getClientConfigNodeNoLock()
+	nodeWithoutLocK, err := DefaultClient().getClientConfigNodeNoLock()
 	assert.NoError(t, err)
 
 	nodes := []*yaml.Node{nodeWithLock, nodeWithoutLocK}
@@ -316,7 +316,7 @@ func TestGetClientConfigWithLockAndMigratedToNewConfig(t *testing.T) {
 	}()
 
 	//Actions
-	node, err := getClientConfigNode()
+	node, err := DefaultClient().getClientConfigNode()
 
 	// Assertions
 	assert.NotNil(t, node)
@@ -369,7 +369,7 @@ func TestGetClientConfigWithoutLockAndMigratedToNewConfig(t *testing.T) {
 
 	//Actions
 	AcquireTanzuConfigNextGenLock()
-	node, err := getClientConfigNodeNoLock()
+	node, err := DefaultClient().getClientConfigNodeNoLock()
 	ReleaseTanzuConfigNextGenLock()
 
 	// Assertions
@@ -422,11 +422,11 @@ func TestPersistConfig(t *testing.T) {
 	}()
 
 	// Actions
-	node, err := getClientConfigNode()
+	node, err := DefaultClient().getClientConfigNode()
 	assert.NotNil(t, node)
 	assert.NoError(t, err)
 
-	err = persistConfig(node)
+	err = DefaultClient().persistConfig(node)
 	assert.NoError(t, err)
 
 	cfgFileData, err := os.ReadFile(cfgTestFiles[0].Name())
@@ -480,11 +480,11 @@ currentContext:
 	}()
 
 	// Actions
-	node, err := getClientConfigNode()
+	node, err := DefaultClient().getClientConfigNode()
 	assert.NotNil(t, node)
 	assert.NoError(t, err)
 
-	err = persistConfig(node)
+	err = DefaultClient().persistConfig(node)
 	assert.NoError(t, err)
 
 	cfgFileData, err := os.ReadFile(cfgTestFiles[0].Name())
@@ -1208,7 +1208,7 @@ current:
 				cleanUp()
 			}()
 
-			multiNode, err := getMultiConfig()
+			multiNode, err := DefaultClient().getMultiConfig()
 			assert.NoError(t, err)
 
 			multiBytes, err := yaml.Marshal(multiNode)
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 
 	"gopkg.in/yaml.v3"
@@ -15,8 +16,13 @@ import (
 
 // GetContext retrieves the context by name
 func GetContext(name string) (*configtypes.Context, error) {
+	return DefaultClient().GetContext(name)
+}
+
+// GetContext retrieves the context by name
+func (client *Client) GetContext(name string) (*configtypes.Context, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -25,123 +31,93 @@ func GetContext(name string) (*configtypes.Context, error) {
 
 // AddContext add or update context and currentContext
 func AddContext(c *configtypes.Context, setCurrent bool) error {
-	return SetContext(c, setCurrent)
+	return DefaultClient().AddContext(c, setCurrent)
+}
+
+// AddContext add or update context and currentContext
+func (client *Client) AddContext(c *configtypes.Context, setCurrent bool) error {
+	return client.SetContext(c, setCurrent)
 }
 
 // SetContext add or update context and currentContext
-//
-//nolint:gocyclo
 func SetContext(c *configtypes.Context, setCurrent bool) error {
+	return DefaultClient().SetContext(c, setCurrent)
+}
+
+// SetContext add or update context and currentContext
+func (client *Client) SetContext(c *configtypes.Context, setCurrent bool) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
-	if err != nil {
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
 		return err
 	}
-	// Add or update the context
-	persist, err := setContext(node, c)
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
-	if persist {
-		err = persistConfig(node)
-		if err != nil {
-			return err
-		}
-	}
-	// Set current context
-	if setCurrent {
-		persist, err = setCurrentContext(node, c)
-		if err != nil {
-			return err
-		}
-		if persist {
-			err = persistConfig(node)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	// Back-fill servers based on contexts
-	s := convertContextToServer(c)
-
-	// Add or update server
-	persist, err = setServer(node, s)
+	persist, err := client.setContextAndServer(node, c, setCurrent)
 	if err != nil {
 		return err
 	}
 	if persist {
-		err = persistConfig(node)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Set current server
-	if setCurrent && s.Type == configtypes.ManagementClusterServerType { // nolint:staticcheck
-		persist, err = setCurrentServer(node, s.Name)
-		if err != nil {
-			return err
-		}
-		if persist {
-			err = persistConfig(node)
-			if err != nil {
-				return err
-			}
-		}
+		return client.persistConfig(node)
 	}
-	return err
+	return nil
 }
 
 // DeleteContext delete a context by name
 func DeleteContext(name string) error {
-	return RemoveContext(name)
+	return DefaultClient().DeleteContext(name)
+}
+
+// DeleteContext delete a context by name
+func (client *Client) DeleteContext(name string) error {
+	return client.RemoveContext(name)
 }
 
 // RemoveContext delete a context by name
 func RemoveContext(name string) error {
+	return DefaultClient().RemoveContext(name)
+}
+
+// RemoveContext delete a context by name
+func (client *Client) RemoveContext(name string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
-	if err != nil {
-		return err
-	}
-	ctx, err := getContext(node, name)
-	if err != nil {
-		return err
-	}
-	err = removeCurrentContext(node, ctx)
-	if err != nil {
-		return err
-	}
-	err = removeContext(node, name)
-	if err != nil {
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
 		return err
 	}
-	err = removeServer(node, name)
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
-	err = removeCurrentServer(node, name)
+	err = removeContextAndServer(node, name)
 	if err != nil {
 		return err
 	}
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 // ContextExists checks if context by name already exists
 func ContextExists(name string) (bool, error) {
-	exists, _ := GetContext(name)
+	return DefaultClient().ContextExists(name)
+}
+
+// ContextExists checks if context by name already exists
+func (client *Client) ContextExists(name string) (bool, error) {
+	exists, _ := client.GetContext(name)
 	return exists != nil, nil
 }
 
 // GetCurrentContext retrieves the current context for the specified target
 func GetCurrentContext(target configtypes.Target) (c *configtypes.Context, err error) {
+	return DefaultClient().GetCurrentContext(target)
+}
+
+// GetCurrentContext retrieves the current context for the specified target
+func (client *Client) GetCurrentContext(target configtypes.Target) (c *configtypes.Context, err error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +126,12 @@ func GetCurrentContext(target configtypes.Target) (c *configtypes.Context, err e
 
 // GetAllCurrentContextsMap returns all current context per Target
 func GetAllCurrentContextsMap() (map[configtypes.Target]*configtypes.Context, error) {
-	node, err := getClientConfigNodeNoLock()
+	return DefaultClient().GetAllCurrentContextsMap()
+}
+
+// GetAllCurrentContextsMap returns all current context per Target
+func (client *Client) GetAllCurrentContextsMap() (map[configtypes.Target]*configtypes.Context, error) {
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +140,12 @@ func GetAllCurrentContextsMap() (map[configtypes.Target]*configtypes.Context, er
 
 // GetAllCurrentContextsList returns all current context names as list
 func GetAllCurrentContextsList() ([]string, error) {
-	currentContextsMap, err := GetAllCurrentContextsMap()
+	return DefaultClient().GetAllCurrentContextsList()
+}
+
+// GetAllCurrentContextsList returns all current context names as list
+func (client *Client) GetAllCurrentContextsList() ([]string, error) {
+	currentContextsMap, err := client.GetAllCurrentContextsMap()
 	if err != nil {
 		return nil, err
 	}
@@ -172,65 +158,51 @@ func GetAllCurrentContextsList() ([]string, error) {
 
 // SetCurrentContext sets the current context to the specified name if context is present
 func SetCurrentContext(name string) error {
+	return DefaultClient().SetCurrentContext(name)
+}
+
+// SetCurrentContext sets the current context to the specified name if context is present
+func (client *Client) SetCurrentContext(name string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
-	if err != nil {
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
 		return err
 	}
-
-	ctx, err := getContext(node, name)
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
-	persist, err := setCurrentContext(node, ctx)
+	persist, err := setCurrentContextAndServer(node, name)
 	if err != nil {
 		return err
 	}
 	if persist {
-		err = persistConfig(node)
-		if err != nil {
-			return err
-		}
+		return client.persistConfig(node)
 	}
-	if ctx.Target == configtypes.TargetK8s {
-		persist, err = setCurrentServer(node, name)
-		if err != nil {
-			return err
-		}
-		if persist {
-			err = persistConfig(node)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return err
+	return nil
 }
 
 // RemoveCurrentContext removed the current context of specified context type
 func RemoveCurrentContext(target configtypes.Target) error {
+	return DefaultClient().RemoveCurrentContext(target)
+}
+
+// RemoveCurrentContext removed the current context of specified context type
+func (client *Client) RemoveCurrentContext(target configtypes.Target) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
-	if err != nil {
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
 		return err
 	}
-	c, err := getCurrentContext(node, target)
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
-	err = removeCurrentContext(node, &configtypes.Context{Target: target})
+	err = removeCurrentContextAndServer(node, target)
 	if err != nil {
 		return err
 	}
-	err = removeCurrentServer(node, c.Name)
-	if err != nil {
-		return err
-	}
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 // EndpointFromContext retrieved the endpoint from the specified context
@@ -255,7 +227,7 @@ func getContext(node *yaml.Node, name string) (*configtypes.Context, error) {
 			return ctx, nil
 		}
 	}
-	return nil, fmt.Errorf("context %v not found", name)
+	return nil, notFound(ErrContextNotFound, name, "context %v not found", name)
 }
 
 func getCurrentContext(node *yaml.Node, target configtypes.Target) (*configtypes.Context, error) {
@@ -263,7 +235,15 @@ func getCurrentContext(node *yaml.Node, target configtypes.Target) (*configtypes
 	if err != nil {
 		return nil, err
 	}
-	return cfg.GetCurrentContext(target)
+	ctxName := cfg.CurrentContext[target]
+	if ctxName == "" {
+		return nil, notFound(ErrCurrentContextNotFound, string(target), "no current context set for target %q", target)
+	}
+	ctx, err := cfg.GetContext(ctxName)
+	if err != nil {
+		return nil, notFound(ErrContextNotFound, ctxName, "unable to get current context: %s", err.Error())
+	}
+	return ctx, nil
 }
 
 func getAllCurrentContextsMap(node *yaml.Node) (map[configtypes.Target]*configtypes.Context, error) {
@@ -274,9 +254,106 @@ func getAllCurrentContextsMap(node *yaml.Node) (map[configtypes.Target]*configty
 	return cfg.GetAllCurrentContextsMap()
 }
 
-func setContexts(node *yaml.Node, contexts []*configtypes.Context) (err error) {
+// setContextAndServer adds or updates the context, and optionally sets it as
+// the current context, back-filling the matching server in the node
+func (client *Client) setContextAndServer(node *yaml.Node, c *configtypes.Context, setCurrent bool) (persist bool, err error) {
+	// Add or update the context
+	persist, err = client.setContext(node, c)
+	if err != nil {
+		return false, err
+	}
+	var updated bool
+	// Set current context
+	if setCurrent {
+		updated, err = setCurrentContext(node, c)
+		if err != nil {
+			return false, err
+		}
+		persist = persist || updated
+	}
+
+	// Back-fill servers based on contexts
+	s := convertContextToServer(c)
+
+	// Add or update server
+	updated, err = client.setServer(node, s)
+	if err != nil {
+		return false, err
+	}
+	persist = persist || updated
+
+	// Set current server
+	if setCurrent && s.Type == configtypes.ManagementClusterServerType { // nolint:staticcheck
+		updated, err = setCurrentServer(node, s.Name)
+		if err != nil {
+			return false, err
+		}
+		persist = persist || updated
+	}
+	return persist, nil
+}
+
+// setCurrentContextAndServer sets the current context to the named context,
+// and the current server for kubernetes contexts, in the node
+func setCurrentContextAndServer(node *yaml.Node, name string) (persist bool, err error) {
+	ctx, err := getContext(node, name)
+	if err != nil {
+		return false, err
+	}
+	persist, err = setCurrentContext(node, ctx)
+	if err != nil {
+		return false, err
+	}
+	if ctx.Target == configtypes.TargetK8s {
+		var updated bool
+		updated, err = setCurrentServer(node, name)
+		if err != nil {
+			return false, err
+		}
+		persist = persist || updated
+	}
+	return persist, nil
+}
+
+// removeCurrentContextAndServer removes the current context of the target,
+// and the matching current server, from the node
+func removeCurrentContextAndServer(node *yaml.Node, target configtypes.Target) error {
+	c, err := getCurrentContext(node, target)
+	if err != nil {
+		return err
+	}
+	err = removeCurrentContext(node, &configtypes.Context{Target: target})
+	if err != nil {
+		return err
+	}
+	return removeCurrentServer(node, c.Name)
+}
+
+// removeContextAndServer removes the context, the matching server, and their
+// current references from the node
+func removeContextAndServer(node *yaml.Node, name string) error {
+	ctx, err := getContext(node, name)
+	if err != nil {
+		return err
+	}
+	err = removeCurrentContext(node, ctx)
+	if err != nil {
+		return err
+	}
+	err = removeContext(node, name)
+	if err != nil {
+		return err
+	}
+	err = removeServer(node, name)
+	if err != nil {
+		return err
+	}
+	return removeCurrentServer(node, name)
+}
+
+func (client *Client) setContexts(node *yaml.Node, contexts []*configtypes.Context) (err error) {
 	for _, c := range contexts {
-		_, err = setContext(node, c)
+		_, err = client.setContext(node, c)
 		if err != nil {
 			return err
 		}
@@ -284,9 +361,9 @@ func setContexts(node *yaml.Node, contexts []*configtypes.Context) (err error) {
 	return err
 }
 
-func setContext(node *yaml.Node, ctx *configtypes.Context) (persist bool, err error) {
+func (client *Client) setContext(node *yaml.Node, ctx *configtypes.Context) (persist bool, err error) {
 	// Get Patch Strategies from config metadata
-	patchStrategies, err := GetConfigMetadataPatchStrategy()
+	patchStrategies, err := client.GetConfigMetadataPatchStrategy()
 	if err != nil {
 		patchStrategies = make(map[string]string)
 	}
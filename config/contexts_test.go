@@ -553,7 +553,7 @@ func TestSetContext(t *testing.T) {
 				},
 			},
 		}
-		err := persistConfig(node)
+		err := DefaultClient().persistConfig(node)
 		assert.NoError(t, err)
 	}()
 	defer func() {
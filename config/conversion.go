@@ -156,7 +156,7 @@ func convertClusterOptsToMgmtClusterOpts(o *configtypes.ClusterServer) *configty
 func convertNodeToClientConfig(node *yaml.Node) (obj *configtypes.ClientConfig, err error) {
 	err = node.Decode(&obj)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert node to ClientConfig")
+		return nil, configCorrupt("", errors.Wrap(err, "failed to convert node to ClientConfig"))
 	}
 	if obj == nil {
 		return &configtypes.ClientConfig{}, err
@@ -168,7 +168,7 @@ func convertNodeToClientConfig(node *yaml.Node) (obj *configtypes.ClientConfig,
 func convertNodeToMetadata(node *yaml.Node) (obj *configtypes.Metadata, err error) {
 	err = node.Decode(&obj)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert node to Metadata")
+		return nil, configCorrupt("", errors.Wrap(err, "failed to convert node to Metadata"))
 	}
 	return obj, err
 }
@@ -66,7 +66,7 @@ func setDiscoverySource(discoverySourcesNode *yaml.Node, discoverySource configt
 	// Get discovery source type and name
 	newOrUpdatedDiscoverySourceType, newOrUpdatedDiscoverySourceName := getDiscoverySourceTypeAndName(discoverySource)
 	if newOrUpdatedDiscoverySourceType == "" || newOrUpdatedDiscoverySourceName == "" {
-		return persist, errors.New("not found")
+		return persist, errors.New("invalid discovery source: the discovery source type and name are required")
 	}
 
 	// Loop through each discovery source node
@@ -4,7 +4,8 @@
 package config
 
 import (
-	"github.com/pkg/errors"
+	"context"
+
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
@@ -12,8 +13,13 @@ import (
 
 // GetAllEnvs retrieves all env values from config
 func GetAllEnvs() (map[string]string, error) {
+	return DefaultClient().GetAllEnvs()
+}
+
+// GetAllEnvs retrieves all env values from config
+func (client *Client) GetAllEnvs() (map[string]string, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -28,13 +34,18 @@ func getAllEnvs(node *yaml.Node) (map[string]string, error) {
 	if cfg.ClientOptions != nil && cfg.ClientOptions.Env != nil {
 		return cfg.ClientOptions.Env, nil
 	}
-	return nil, errors.New("not found")
+	return nil, notFound(ErrEnvNotFound, "", "not found")
 }
 
 // GetEnv retrieves env value by key
 func GetEnv(key string) (string, error) {
+	return DefaultClient().GetEnv(key)
+}
+
+// GetEnv retrieves env value by key
+func (client *Client) GetEnv(key string) (string, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return "", err
 	}
@@ -46,21 +57,28 @@ func getEnv(node *yaml.Node, key string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if cfg.ClientOptions == nil && cfg.ClientOptions.Env == nil {
-		return "", errors.New("not found")
+	if cfg.ClientOptions == nil || cfg.ClientOptions.Env == nil {
+		return "", notFound(ErrEnvNotFound, key, "not found")
 	}
 	if val, ok := cfg.ClientOptions.Env[key]; ok {
 		return val, nil
 	}
-	return "", errors.New("not found")
+	return "", notFound(ErrEnvNotFound, key, "not found")
 }
 
 // DeleteEnv delete the env entry of specified key
 func DeleteEnv(key string) error {
+	return DefaultClient().DeleteEnv(key)
+}
+
+// DeleteEnv delete the env entry of specified key
+func (client *Client) DeleteEnv(key string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -68,7 +86,7 @@ func DeleteEnv(key string) error {
 	if err != nil {
 		return err
 	}
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 func deleteEnv(node *yaml.Node, key string) (err error) {
@@ -102,10 +120,17 @@ func deleteEnv(node *yaml.Node, key string) (err error) {
 
 // SetEnv add or update a env key and value
 func SetEnv(key, value string) (err error) {
+	return DefaultClient().SetEnv(key, value)
+}
+
+// SetEnv add or update a env key and value
+func (client *Client) SetEnv(key, value string) (err error) {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err = client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -116,7 +141,7 @@ func SetEnv(key, value string) (err error) {
 		return err
 	}
 	if persist {
-		return persistConfig(node)
+		return client.persistConfig(node)
 	}
 	return err
 }
@@ -159,7 +184,14 @@ func setEnv(node *yaml.Node, key, value string) (persist bool, err error) {
 // to values as part of tanzu configuration file
 // it returns nil if configuration is not yet defined
 func GetEnvConfigurations() map[string]string {
-	envs, err := GetAllEnvs()
+	return DefaultClient().GetEnvConfigurations()
+}
+
+// GetEnvConfigurations returns a map of configured environment variables
+// to values as part of tanzu configuration file
+// it returns nil if configuration is not yet defined
+func (client *Client) GetEnvConfigurations() map[string]string {
+	envs, err := client.GetAllEnvs()
 	if err != nil {
 		return make(map[string]string)
 	}
@@ -0,0 +1,122 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotFound matches all the errors of items not found in the config
+var ErrNotFound = errors.New("not found")
+
+// Sentinel errors of the items not found in the config, which also match ErrNotFound
+var (
+	ErrContextNotFound               = errors.New("context not found")
+	ErrCurrentContextNotFound        = errors.New("current context not found")
+	ErrServerNotFound                = errors.New("server not found")
+	ErrCurrentServerNotFound         = errors.New("current server not found")
+	ErrEnvNotFound                   = errors.New("env not found")
+	ErrFeatureNotFound               = errors.New("feature not found")
+	ErrCLIRepositoryNotFound         = errors.New("cli repository not found")
+	ErrCLIDiscoverySourceNotFound    = errors.New("cli discovery source not found")
+	ErrEditionNotFound               = errors.New("edition not found")
+	ErrCEIPOptInNotFound             = errors.New("ceipOptIn not found")
+	ErrConfigMetadataNotFound        = errors.New("config metadata not found")
+	ErrConfigMetadataSettingNotFound = errors.New("config metadata setting not found")
+	ErrPatchStrategyNotFound         = errors.New("config metadata patch strategy not found")
+	ErrSnapshotNotFound              = errors.New("config snapshot not found")
+)
+
+// ErrConfigCorrupt matches the errors of config files which cannot be parsed
+var ErrConfigCorrupt = errors.New("config corrupt")
+
+// NotFoundError is the error of an item not found in the config. It matches the
+// sentinel error of the kind of item, such as ErrContextNotFound, and ErrNotFound.
+type NotFoundError struct {
+	// Err is the sentinel error of the kind of item
+	Err error
+
+	// Name is the name of the item, if any
+	Name string
+
+	msg string
+}
+
+// notFound returns the error of an item not found, with the specified message
+func notFound(kind error, name, format string, args ...interface{}) error {
+	return &NotFoundError{Err: kind, Name: name, msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *NotFoundError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	if e.Name != "" {
+		return fmt.Sprintf("%v: %s", e.Err, e.Name)
+	}
+	return e.Err.Error()
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrNotFound
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ConfigCorruptError is the error of a config file which cannot be parsed. It matches
+// ErrConfigCorrupt.
+type ConfigCorruptError struct {
+	// Path is the path of the config file, if known
+	Path string
+
+	// Line is the line of the error in the config file, or 0 if unknown
+	Line int
+
+	// Err is the parsing error
+	Err error
+}
+
+var yamlErrorLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// configCorrupt returns the error of the config file which cannot be parsed
+func configCorrupt(path string, err error) error {
+	corruptErr := &ConfigCorruptError{Path: path, Err: err}
+	var typeErr *yaml.TypeError
+	msg := err.Error()
+	if errors.As(err, &typeErr) && len(typeErr.Errors) != 0 {
+		msg = typeErr.Errors[0]
+	}
+	if match := yamlErrorLineRegexp.FindStringSubmatch(msg); match != nil {
+		corruptErr.Line, _ = strconv.Atoi(match[1])
+	}
+	return corruptErr
+}
+
+func (e *ConfigCorruptError) Error() string {
+	location := e.Path
+	if location == "" {
+		location = "config"
+	}
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, e.Line)
+	}
+	return fmt.Sprintf("%s is corrupt: %v", location, e.Err)
+}
+
+func (e *ConfigCorruptError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrConfigCorrupt
+func (e *ConfigCorruptError) Is(target error) bool {
+	return target == ErrConfigCorrupt
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestNotFoundErrors(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(nil)))
+
+	_, err := client.GetContext("missing")
+	assert.True(t, errors.Is(err, ErrContextNotFound))
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrEnvNotFound))
+	assert.EqualError(t, err, "context missing not found")
+	var notFoundErr *NotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, "missing", notFoundErr.Name)
+
+	_, err = client.GetEnv("missing")
+	assert.True(t, errors.Is(err, ErrEnvNotFound))
+	assert.NoError(t, client.SetEnv("key", "value"))
+	_, err = client.GetEnv("missing")
+	assert.True(t, errors.Is(err, ErrEnvNotFound))
+
+	_, err = client.IsFeatureEnabled("plugin", "missing")
+	assert.True(t, errors.Is(err, ErrFeatureNotFound))
+
+	_, err = client.GetCurrentContext(configtypes.TargetK8s)
+	assert.True(t, errors.Is(err, ErrCurrentContextNotFound))
+	_, err = client.GetServer("missing")
+	assert.True(t, errors.Is(err, ErrServerNotFound))
+	_, err = client.GetCLIRepository("missing")
+	assert.True(t, errors.Is(err, ErrCLIRepositoryNotFound))
+	_, err = client.GetCLIDiscoverySource("missing")
+	assert.True(t, errors.Is(err, ErrCLIDiscoverySourceNotFound))
+	_, err = client.GetConfigMetadataSetting("missing")
+	assert.True(t, errors.Is(err, ErrConfigMetadataSettingNotFound))
+	_, err = client.GetConfigMetadataPatchStrategy()
+	assert.True(t, errors.Is(err, ErrPatchStrategyNotFound))
+}
+
+func TestConfigCorruptErrors(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(map[ConfigFile][]byte{
+		ClientConfigNextGenFile: []byte("contexts:\n  - name: test\n    target: [kubernetes\n"),
+	})))
+
+	_, err := client.GetContext("test")
+	assert.True(t, errors.Is(err, ErrConfigCorrupt))
+	assert.False(t, errors.Is(err, ErrNotFound))
+	var corruptErr *ConfigCorruptError
+	assert.True(t, errors.As(err, &corruptErr))
+	assert.Equal(t, string(ClientConfigNextGenFile), corruptErr.Path)
+	assert.NotZero(t, corruptErr.Line)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ConfigName), []byte("clientOptions:\n  env:\n    - key\n"), 0o600))
+	_, err = NewClient(WithLocalDir(dir)).GetEnv("key")
+	assert.True(t, errors.As(err, &corruptErr))
+	assert.Equal(t, 3, corruptErr.Line)
+	assert.Contains(t, err.Error(), "config:3 is corrupt")
+
+	_, err = NewClient(WithLocalDir(dir)).GetContext("test")
+	assert.True(t, errors.Is(err, ErrConfigCorrupt))
+}
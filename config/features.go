@@ -4,10 +4,10 @@
 package config
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
-	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
@@ -15,11 +15,20 @@ import (
 
 // IsFeatureEnabled checks and returns whether specific plugin and key is true
 func IsFeatureEnabled(plugin, key string) (bool, error) {
+	return DefaultClient().IsFeatureEnabled(plugin, key)
+}
+
+// IsFeatureEnabled checks and returns whether specific plugin and key is true
+func (client *Client) IsFeatureEnabled(plugin, key string) (bool, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return false, err
 	}
+	return isFeatureEnabled(node, plugin, key)
+}
+
+func isFeatureEnabled(node *yaml.Node, plugin, key string) (bool, error) {
 	val, err := getFeature(node, plugin, key)
 	if err != nil {
 		return false, err
@@ -36,20 +45,27 @@ func getFeature(node *yaml.Node, plugin, key string) (string, error) {
 		return "", err
 	}
 	if cfg.ClientOptions == nil || cfg.ClientOptions.Features == nil || cfg.ClientOptions.Features[plugin] == nil {
-		return "", errors.New("not found")
+		return "", notFound(ErrFeatureNotFound, plugin+"."+key, "not found")
 	}
 	if val, ok := cfg.ClientOptions.Features[plugin][key]; ok {
 		return val, nil
 	}
-	return "", errors.New("not found")
+	return "", notFound(ErrFeatureNotFound, plugin+"."+key, "not found")
 }
 
 // DeleteFeature deletes the specified plugin key
 func DeleteFeature(plugin, key string) error {
+	return DefaultClient().DeleteFeature(plugin, key)
+}
+
+// DeleteFeature deletes the specified plugin key
+func (client *Client) DeleteFeature(plugin, key string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -57,7 +73,7 @@ func DeleteFeature(plugin, key string) error {
 	if err != nil {
 		return err
 	}
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 func deleteFeature(node *yaml.Node, plugin, key string) error {
@@ -86,10 +102,17 @@ func deleteFeature(node *yaml.Node, plugin, key string) error {
 
 // SetFeature add or update plugin key value
 func SetFeature(plugin, key, value string) (err error) {
+	return DefaultClient().SetFeature(plugin, key, value)
+}
+
+// SetFeature add or update plugin key value
+func (client *Client) SetFeature(plugin, key, value string) (err error) {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err = client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -99,7 +122,7 @@ func SetFeature(plugin, key, value string) (err error) {
 		return err
 	}
 	if persist {
-		return persistConfig(node)
+		return client.persistConfig(node)
 	}
 	return err
 }
@@ -130,9 +153,16 @@ func setFeature(node *yaml.Node, plugin, key, value string) (persist bool, err e
 
 // ConfigureDefaultFeatureFlagsIfMissing add or update plugin features based on specified default feature flags
 func ConfigureDefaultFeatureFlagsIfMissing(plugin string, defaultFeatureFlags map[string]bool) error {
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	return DefaultClient().ConfigureDefaultFeatureFlagsIfMissing(plugin, defaultFeatureFlags)
+}
+
+// ConfigureDefaultFeatureFlagsIfMissing add or update plugin features based on specified default feature flags
+func (client *Client) ConfigureDefaultFeatureFlagsIfMissing(plugin string, defaultFeatureFlags map[string]bool) error {
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -160,7 +190,13 @@ func ConfigureDefaultFeatureFlagsIfMissing(plugin string, defaultFeatureFlags ma
 // IsFeatureActivated returns true if the given feature is activated
 // User can set this CLI feature flag using `tanzu config set features.global.<feature> true`
 func IsFeatureActivated(feature string) bool {
-	cfg, err := GetClientConfig()
+	return DefaultClient().IsFeatureActivated(feature)
+}
+
+// IsFeatureActivated returns true if the given feature is activated
+// User can set this CLI feature flag using `tanzu config set features.global.<feature> true`
+func (client *Client) IsFeatureActivated(feature string) bool {
+	cfg, err := client.GetClientConfig()
 	if err != nil {
 		return false
 	}
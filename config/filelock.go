@@ -0,0 +1,78 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/fslock"
+)
+
+// lockPollInterval is the time between attempts to acquire a lock file held by another process
+const lockPollInterval = 100 * time.Millisecond
+
+// acquireFileLock acquires the lock file, waiting until the context is done, and records
+// the process holding the lock in the lock file. The lock file is never removed, as the
+// lock of a process which is no longer running is released by the operating system.
+func acquireFileLock(ctx context.Context, lockPath string) (*fslock.Lock, error) {
+	dir := filepath.Dir(lockPath)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, &LockError{Path: lockPath, Err: err}
+		}
+	}
+
+	for {
+		lock := fslock.New(lockPath)
+		err := lock.TryLock()
+		if err == nil {
+			writeLockHolder(lockPath)
+			return lock, nil
+		}
+		if err != fslock.ErrLocked {
+			return nil, &LockError{Path: lockPath, Err: err}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, &LockError{Path: lockPath, Holder: readLockHolder(lockPath), Err: ctx.Err()}
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// releaseFileLock clears the holder recorded in the lock file and releases the lock
+func releaseFileLock(lock *fslock.Lock, lockPath string) error {
+	_ = os.Truncate(lockPath, 0)
+	return lock.Unlock()
+}
+
+// writeLockHolder records the current process as the holder of the lock. This is best
+// effort, as the lock file may not be writable while locked on some platforms.
+func writeLockHolder(lockPath string) {
+	host, _ := os.Hostname()
+	data, err := json.Marshal(&LockHolder{PID: os.Getpid(), Host: host, AcquiredAt: time.Now()})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(lockPath, data, 0o600)
+}
+
+// readLockHolder returns the holder recorded in the lock file, if any. The record may be
+// outdated, as it is written after acquiring the lock.
+func readLockHolder(lockPath string) *LockHolder {
+	data, err := os.ReadFile(lockPath)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	holder := &LockHolder{}
+	if err := json.Unmarshal(data, holder); err != nil {
+		return nil
+	}
+	return holder
+}
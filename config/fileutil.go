@@ -10,6 +10,9 @@ import (
 	"path/filepath"
 )
 
+// backupSuffix is the suffix of the backup files, holding the previous version of the config files
+const backupSuffix = ".bak"
+
 // copyFile copies a file from source to destination while preserving permissions. If the destination file does not
 // exist, the file will be created. If the file exists, its contents will be *overwritten*.
 func copyFile(src, dst string) error {
@@ -103,3 +106,67 @@ func fileExists(filename string) (bool, error) {
 	}
 	return true, nil
 }
+
+// writeFileAtomic writes data to a temporary file in the directory of path, syncs it to disk and renames it
+// over path, so that path holds either its previous or its new content even if the write is interrupted. The
+// mode of an existing file is preserved, perm is used for new files. If path is a symlink, the file it links to
+// is replaced and the symlink is kept.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	path, err = resolveSymlinks(path)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if info, statErr := os.Stat(path); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+	if err = os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// resolveSymlinks returns the path of the file a symlink links to, or path itself if it does not exist
+func resolveSymlinks(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if os.IsNotExist(err) {
+		return path, nil
+	}
+	return resolved, err
+}
+
+// syncDir syncs a directory to disk, so that a file renamed in the directory persists. Errors are ignored, as
+// directories cannot be synced on all platforms.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
@@ -4,8 +4,6 @@
 package config
 
 import (
-	"os"
-
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
@@ -42,43 +40,22 @@ func CopyLegacyConfigDir() error {
 }
 
 // storeConfigToLegacyDir stores configuration to legacy dir and logs warning in case of errors.
-func storeConfigToLegacyDir(data []byte) {
-	var (
-		err                      error
-		legacyDir, legacyCfgPath string
-		legacyDirExists          bool
-	)
-
-	defer func() {
-		if err != nil {
-			log.Warningf("Failed to write config to legacy location for backward compatibility: %v", err)
-			log.Warningf("To stop writing config to legacy location, please point your script(s), "+
-				"if any, to the new config directory and remove legacy config directory %s", legacyDir)
-		}
-	}()
-
-	legacyDir, err = legacyLocalDir()
-	if err != nil {
-		return
-	}
-	legacyDirExists, err = fileExists(legacyDir)
-	if err != nil || !legacyDirExists {
-		// Assume user has migrated and ignore writing to legacy location if that dir does not exist.
-		return
-	}
-	legacyCfgPath, err = legacyConfigPath()
+func (client *Client) storeConfigToLegacyDir(data []byte) {
+	err := client.storage.Write(LegacyClientConfigFile, data)
 	if err != nil {
-		return
+		legacyDir, _ := legacyLocalDir()
+		log.Warningf("Failed to write config to legacy location for backward compatibility: %v", err)
+		log.Warningf("To stop writing config to legacy location, please point your script(s), "+
+			"if any, to the new config directory and remove legacy config directory %s", legacyDir)
 	}
-	err = os.WriteFile(legacyCfgPath, data, 0644)
 }
 
 // persistLegacyClientConfig write to config.yaml
-func persistLegacyClientConfig(node *yaml.Node) error {
+func (client *Client) persistLegacyClientConfig(node *yaml.Node) error {
 	data, err := yaml.Marshal(node)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal nodeutils")
 	}
-	storeConfigToLegacyDir(data)
+	client.storeConfigToLegacyDir(data)
 	return nil
 }
@@ -4,8 +4,6 @@
 package config
 
 import (
-	"os"
-
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
@@ -14,8 +12,13 @@ import (
 
 // GetClientConfig retrieves the config from the local directory with file lock
 func GetClientConfig() (cfg *configtypes.ClientConfig, err error) {
+	return DefaultClient().GetClientConfig()
+}
+
+// GetClientConfig retrieves the config from the local directory with file lock
+func (client *Client) GetClientConfig() (cfg *configtypes.ClientConfig, err error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +33,12 @@ func GetClientConfig() (cfg *configtypes.ClientConfig, err error) {
 
 // GetClientConfigNoLock retrieves the config from the local directory without acquiring the lock
 func GetClientConfigNoLock() (cfg *configtypes.ClientConfig, err error) {
-	node, err := getClientConfigNodeNoLock()
+	return DefaultClient().GetClientConfigNoLock()
+}
+
+// GetClientConfigNoLock retrieves the config from the local directory without acquiring the lock
+func (client *Client) GetClientConfigNoLock() (cfg *configtypes.ClientConfig, err error) {
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return nil, err
 	}
@@ -47,16 +55,24 @@ func GetClientConfigNoLock() (cfg *configtypes.ClientConfig, err error) {
 // tanzu client configuration
 // Deprecated: StoreClientConfig is deprecated. Avoid using this method for Delete operations. Use New Config API methods.
 func StoreClientConfig(cfg *configtypes.ClientConfig) error {
+	return DefaultClient().StoreClientConfig(cfg)
+}
+
+// StoreClientConfig stores the config in the local directory.
+// Make sure to Acquire and Release tanzu lock when reading/writing to the
+// tanzu client configuration
+// Deprecated: StoreClientConfig is deprecated. Avoid using this method for Delete operations. Use New Config API methods.
+func (client *Client) StoreClientConfig(cfg *configtypes.ClientConfig) error {
 	// new plugins would be setting only contexts, so populate servers for backwards compatibility
 	populateServers(cfg)
 	// old plugins would be setting only servers, so populate contexts for forwards compatibility
 	PopulateContexts(cfg)
-	node, err := getClientConfigNodeNoLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
 
-	err = setServers(node, cfg.KnownServers)
+	err = client.setServers(node, cfg.KnownServers)
 	if err != nil {
 		return err
 	}
@@ -66,7 +82,7 @@ func StoreClientConfig(cfg *configtypes.ClientConfig) error {
 			return err
 		}
 	}
-	err = setContexts(node, cfg.KnownContexts)
+	err = client.setContexts(node, cfg.KnownContexts)
 	if err != nil {
 		return err
 	}
@@ -74,14 +90,14 @@ func StoreClientConfig(cfg *configtypes.ClientConfig) error {
 	if err != nil {
 		return err
 	}
-	err = clientConfigSetClientOptions(cfg, node)
+	err = client.clientConfigSetClientOptions(cfg, node)
 	if err != nil {
 		return err
 	}
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
-func clientConfigSetClientOptions(cfg *configtypes.ClientConfig, node *yaml.Node) error {
+func (client *Client) clientConfigSetClientOptions(cfg *configtypes.ClientConfig, node *yaml.Node) error {
 	if cfg.ClientOptions != nil {
 		err := clientConfigSetFeatures(cfg, node)
 		if err != nil {
@@ -91,7 +107,7 @@ func clientConfigSetClientOptions(cfg *configtypes.ClientConfig, node *yaml.Node
 		if err != nil {
 			return err
 		}
-		err = clientConfigSetCLI(cfg, node)
+		err = client.clientConfigSetCLI(cfg, node)
 		if err != nil {
 			return err
 		}
@@ -99,13 +115,13 @@ func clientConfigSetClientOptions(cfg *configtypes.ClientConfig, node *yaml.Node
 	return nil
 }
 
-func clientConfigSetCLI(cfg *configtypes.ClientConfig, node *yaml.Node) (err error) {
+func (client *Client) clientConfigSetCLI(cfg *configtypes.ClientConfig, node *yaml.Node) (err error) {
 	if cfg.ClientOptions.CLI != nil {
-		err = clientConfigSetCLIRepositories(cfg, node)
+		err = client.clientConfigSetCLIRepositories(cfg, node)
 		if err != nil {
 			return err
 		}
-		err = clientConfigSetCLIDiscoverySources(cfg, node)
+		err = client.clientConfigSetCLIDiscoverySources(cfg, node)
 		if err != nil {
 			return err
 		}
@@ -125,9 +141,9 @@ func clientConfigSetCLI(cfg *configtypes.ClientConfig, node *yaml.Node) (err err
 	return nil
 }
 
-func clientConfigSetCLIDiscoverySources(cfg *configtypes.ClientConfig, node *yaml.Node) error {
+func (client *Client) clientConfigSetCLIDiscoverySources(cfg *configtypes.ClientConfig, node *yaml.Node) error {
 	if cfg.ClientOptions.CLI.DiscoverySources != nil && len(cfg.ClientOptions.CLI.DiscoverySources) != 0 {
-		err := setCLIDiscoverySources(node, cfg.ClientOptions.CLI.DiscoverySources)
+		err := client.setCLIDiscoverySources(node, cfg.ClientOptions.CLI.DiscoverySources)
 		if err != nil {
 			return err
 		}
@@ -135,9 +151,9 @@ func clientConfigSetCLIDiscoverySources(cfg *configtypes.ClientConfig, node *yam
 	return nil
 }
 
-func clientConfigSetCLIRepositories(cfg *configtypes.ClientConfig, node *yaml.Node) error {
+func (client *Client) clientConfigSetCLIRepositories(cfg *configtypes.ClientConfig, node *yaml.Node) error {
 	if cfg.ClientOptions.CLI.Repositories != nil && len(cfg.ClientOptions.CLI.Repositories) != 0 {
-		err := setCLIRepositories(node, cfg.ClientOptions.CLI.Repositories)
+		err := client.setCLIRepositories(node, cfg.ClientOptions.CLI.Repositories)
 		if err != nil {
 			return err
 		}
@@ -189,11 +205,12 @@ func clientConfigSetCurrentContext(cfg *configtypes.ClientConfig, node *yaml.Nod
 
 // DeleteClientConfig deletes the config yaml from the local directory.
 func DeleteClientConfig() error {
-	cfgPath, err := ClientConfigPath()
-	if err != nil {
-		return err
-	}
-	err = os.Remove(cfgPath)
+	return DefaultClient().DeleteClientConfig()
+}
+
+// DeleteClientConfig deletes the config yaml from the local directory.
+func (client *Client) DeleteClientConfig() error {
+	err := client.storage.Remove(ClientConfigFile)
 	if err != nil {
 		return errors.Wrap(err, "could not remove config")
 	}
@@ -202,11 +219,12 @@ func DeleteClientConfig() error {
 
 // DeleteClientConfigNextGen deletes the config-ng yaml from the local directory.
 func DeleteClientConfigNextGen() error {
-	cfgPath, err := ClientConfigNextGenPath()
-	if err != nil {
-		return err
-	}
-	err = os.Remove(cfgPath)
+	return DefaultClient().DeleteClientConfigNextGen()
+}
+
+// DeleteClientConfigNextGen deletes the config-ng yaml from the local directory.
+func (client *Client) DeleteClientConfigNextGen() error {
+	err := client.storage.Remove(ClientConfigNextGenFile)
 	if err != nil {
 		return errors.Wrap(err, "could not remove config-ng")
 	}
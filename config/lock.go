@@ -4,13 +4,10 @@
 package config
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"path/filepath"
-	"sync"
 	"time"
 
-	"github.com/juju/fslock"
 	"github.com/pkg/errors"
 )
 
@@ -20,73 +17,149 @@ const (
 	DefaultLockTimeout = 10 * time.Minute
 )
 
-var tanzuConfigLockFile string
+// ErrLockTimeout is the error of a lock not acquired within the lock timeout of the client
+var ErrLockTimeout = errors.New("timed out waiting for the lock")
 
-// tanzuConfigLock used as a static lock variable that stores fslock
-// This is used for interprocess locking of the config file
-var tanzuConfigLock *fslock.Lock
+// LockHolder describes the process holding the lock of a config file
+type LockHolder struct {
+	PID        int       `json:"pid"`
+	Host       string    `json:"host"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+}
+
+// LockError is the error returned when the lock of a config file cannot be acquired
+type LockError struct {
+	// Path is the path of the lock
+	Path string
+
+	// Holder is the last process recorded in the lock file, if any. It is advisory:
+	// the lock of a process which is no longer running is released by the operating
+	// system, but its record stays in the lock file until the lock is acquired again.
+	Holder *LockHolder
+
+	// Err is the reason the lock was not acquired
+	Err error
+}
+
+func (e *LockError) Error() string {
+	if e.Holder == nil {
+		return fmt.Sprintf("cannot acquire the lock %s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("cannot acquire the lock %s, last recorded holder (may be stale) is process %d on host %s since %s: %v",
+		e.Path, e.Holder.PID, e.Holder.Host, e.Holder.AcquiredAt.Format(time.RFC3339), e.Err)
+}
 
-// mutex is used to handle the locking behavior between concurrent calls
-// within the existing process trying to acquire the lock
-var mutex sync.Mutex
+func (e *LockError) Unwrap() error {
+	return e.Err
+}
 
 // AcquireTanzuConfigLock tries to acquire lock to update tanzu config file with timeout
 func AcquireTanzuConfigLock() {
-	var err error
-
-	if tanzuConfigLockFile == "" {
-		path, err := ClientConfigPath()
-		if err != nil {
-			panic(fmt.Sprintf("cannot get config path while acquiring lock on tanzu config file, reason: %v", err))
-		}
-		tanzuConfigLockFile = filepath.Join(filepath.Dir(path), LocalTanzuFileLock)
-	}
+	DefaultClient().AcquireTanzuConfigLock()
+}
 
-	// using fslock to handle interprocess locking
-	lock, err := getFileLockWithTimeOut(tanzuConfigLockFile, DefaultLockTimeout)
-	if err != nil {
+// AcquireTanzuConfigLock tries to acquire lock to update tanzu config file with timeout
+func (client *Client) AcquireTanzuConfigLock() {
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
 		panic(fmt.Sprintf("cannot acquire lock for tanzu config file, reason: %v", err))
 	}
+}
+
+// AcquireTanzuConfigLockContext acquires the locks of the tanzu config files, waiting
+// until the context is done or the lock timeout expires. The returned *LockError
+// reports the last process recorded as holding the lock.
+func AcquireTanzuConfigLockContext(ctx context.Context) error {
+	return DefaultClient().AcquireTanzuConfigLockContext(ctx)
+}
 
-	// Lock the mutex to prevent concurrent calls to acquire and configure the tanzuConfigLock
-	mutex.Lock()
-	tanzuConfigLock = lock
+// AcquireTanzuConfigLockContext acquires the locks of the tanzu config files, waiting
+// until the context is done or the lock timeout expires. The returned *LockError
+// reports the last process recorded as holding the lock.
+func (client *Client) AcquireTanzuConfigLockContext(ctx context.Context) error {
+	if err := client.lock(ctx, ClientConfigFile); err != nil {
+		return err
+	}
 
 	// Get lock on config-ng.yaml
-	AcquireTanzuConfigNextGenLock()
+	if err := client.AcquireTanzuConfigNextGenLockContext(ctx); err != nil {
+		_ = client.storage.Unlock(ClientConfigFile)
+		return err
+	}
+	return nil
 }
 
 // ReleaseTanzuConfigLock releases the lock if the tanzuConfigLock was acquired
 func ReleaseTanzuConfigLock() {
-	if tanzuConfigLock == nil {
-		return
-	}
-	if errUnlock := tanzuConfigLock.Unlock(); errUnlock != nil {
-		panic(fmt.Sprintf("cannot release lock for tanzu config file, reason: %v", errUnlock))
+	DefaultClient().ReleaseTanzuConfigLock()
+}
+
+// ReleaseTanzuConfigLock releases the lock if the tanzuConfigLock was acquired
+func (client *Client) ReleaseTanzuConfigLock() {
+	if err := client.ReleaseTanzuConfigLockE(); err != nil {
+		panic(fmt.Sprintf("cannot release lock for tanzu config file, reason: %v", err))
 	}
+}
 
-	tanzuConfigLock = nil
-	// Unlock the mutex to allow other concurrent calls to acquire and configure the tanzuConfigLock
-	mutex.Unlock()
+// ReleaseTanzuConfigLockE releases the locks of the tanzu config files if acquired
+func ReleaseTanzuConfigLockE() error {
+	return DefaultClient().ReleaseTanzuConfigLockE()
+}
+
+// ReleaseTanzuConfigLockE releases the locks of the tanzu config files if acquired
+func (client *Client) ReleaseTanzuConfigLockE() error {
+	if err := client.storage.Unlock(ClientConfigFile); err != nil {
+		return err
+	}
 
 	// Release lock on config-ng.yaml
-	ReleaseTanzuConfigNextGenLock()
+	return client.ReleaseTanzuConfigNextGenLockE()
 }
 
-// getFileLockWithTimeOut returns a file lock with timeout
-func getFileLockWithTimeOut(lockPath string, lockDuration time.Duration) (*fslock.Lock, error) {
-	dir := filepath.Dir(lockPath)
+// defaultLockTimeouts are the default times waiting on the locks of the config files
+var defaultLockTimeouts = map[ConfigFile]time.Duration{
+	ClientConfigFile:        DefaultLockTimeout,
+	ClientConfigNextGenFile: DefaultConfigNextGenLockTimeout,
+	MetadataFile:            DefaultMetadataLockTimeout,
+}
 
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		if err := os.MkdirAll(dir, 0o700); err != nil {
-			return nil, err
-		}
+// lock acquires the lock of the config file within the lock timeout of the client,
+// or else the default lock timeout of the file
+func (client *Client) lock(ctx context.Context, file ConfigFile) error {
+	if (file == ClientConfigFile || file == ClientConfigNextGenFile) && client.inTransaction(ctx) {
+		// The lock is held by the transaction, and would never be acquired
+		return errors.Wrapf(ErrInTransaction, "cannot acquire the lock of %s", client.location(file))
+	}
+	timeout := client.lockTimeout
+	if timeout <= 0 {
+		timeout = defaultLockTimeouts[file]
+	}
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout
 	}
+	lockCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	lock := fslock.New(lockPath)
+	err := client.storage.Lock(lockCtx, file)
+	var lockErr *LockError
+	if err != nil && ctx.Err() == nil && errors.Is(lockCtx.Err(), context.DeadlineExceeded) && errors.As(err, &lockErr) {
+		lockErr.Err = ErrLockTimeout
+	}
+	return err
+}
 
-	if err := lock.LockWithTimeout(lockDuration); err != nil {
-		return nil, errors.Wrap(err, "failed to acquire a lock with timeout")
+// lockConfigFiles acquires the locks of the tanzu config files and of the config
+// metadata file, and returns the function releasing them
+func (client *Client) lockConfigFiles(ctx context.Context) (unlock func(), err error) {
+	// The tanzu config lock covers both config.yaml and config-ng.yaml
+	if err = client.AcquireTanzuConfigLockContext(ctx); err != nil {
+		return nil, err
+	}
+	if err = client.AcquireTanzuMetadataLockContext(ctx); err != nil {
+		client.ReleaseTanzuConfigLock()
+		return nil, err
 	}
-	return lock, nil
+	return func() {
+		client.ReleaseTanzuMetadataLock()
+		client.ReleaseTanzuConfigLock()
+	}, nil
 }
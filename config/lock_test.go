@@ -0,0 +1,129 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/juju/fslock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockTimeoutReportsHolder(t *testing.T) {
+	dir := t.TempDir()
+	holder := NewClient(WithLocalDir(dir))
+	waiter := NewClient(WithLocalDir(dir), WithLockTimeout(200*time.Millisecond))
+
+	assert.NoError(t, holder.AcquireTanzuConfigLockContext(context.Background()))
+
+	err := waiter.AcquireTanzuConfigLockContext(context.Background())
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+	var lockErr *LockError
+	assert.True(t, errors.As(err, &lockErr))
+	assert.Equal(t, filepath.Join(dir, LocalTanzuFileLock), lockErr.Path)
+	assert.NotNil(t, lockErr.Holder)
+	assert.Equal(t, os.Getpid(), lockErr.Holder.PID)
+	assert.Contains(t, err.Error(), "last recorded holder (may be stale) is process")
+
+	// The config APIs return the error instead of panicking
+	assert.True(t, errors.Is(waiter.SetEnv("key", "value"), ErrLockTimeout))
+
+	assert.NoError(t, holder.ReleaseTanzuConfigLockE())
+	assert.NoError(t, waiter.SetEnv("key", "value"))
+}
+
+func TestLockContextCanceled(t *testing.T) {
+	dir := t.TempDir()
+	holder := NewClient(WithLocalDir(dir))
+	waiter := NewClient(WithLocalDir(dir))
+
+	assert.NoError(t, holder.AcquireTanzuMetadataLockContext(context.Background()))
+	defer holder.ReleaseTanzuMetadataLock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := waiter.AcquireTanzuMetadataLockContext(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.False(t, errors.Is(err, ErrLockTimeout))
+}
+
+func TestLockWithinProcess(t *testing.T) {
+	client := NewClient(WithLocalDir(t.TempDir()), WithLockTimeout(100*time.Millisecond))
+
+	assert.NoError(t, client.AcquireTanzuConfigLockContext(context.Background()))
+	assert.True(t, errors.Is(client.AcquireTanzuConfigNextGenLockContext(context.Background()), ErrLockTimeout))
+	assert.NoError(t, client.ReleaseTanzuConfigLockE())
+	assert.NoError(t, client.ReleaseTanzuConfigLockE())
+
+	assert.NoError(t, client.AcquireTanzuConfigNextGenLockContext(context.Background()))
+	assert.NoError(t, client.ReleaseTanzuConfigNextGenLockE())
+
+	memory := NewClient(WithStorage(NewMemoryStorage(nil)), WithLockTimeout(50*time.Millisecond))
+	assert.NoError(t, memory.AcquireTanzuMetadataLockContext(context.Background()))
+	assert.True(t, errors.Is(memory.AcquireTanzuMetadataLockContext(context.Background()), ErrLockTimeout))
+}
+
+func TestLockHeldWithOutdatedHolderIsNotRemoved(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, LocalTanzuFileLock)
+
+	// Hold the lock before recording the holder, the lock file still naming a process
+	// which is no longer running
+	cmd := exec.Command("go", "version")
+	assert.NoError(t, cmd.Run())
+	host, err := os.Hostname()
+	assert.NoError(t, err)
+	data, err := json.Marshal(&LockHolder{PID: cmd.ProcessState.Pid(), Host: host, AcquiredAt: time.Now()})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(lockPath, data, 0o600))
+	held := fslock.New(lockPath)
+	assert.NoError(t, held.Lock())
+
+	client := NewClient(WithLocalDir(dir), WithLockTimeout(300*time.Millisecond))
+	err = client.AcquireTanzuConfigLockContext(context.Background())
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+	var lockErr *LockError
+	assert.True(t, errors.As(err, &lockErr))
+	assert.NotNil(t, lockErr.Holder)
+	assert.Equal(t, cmd.ProcessState.Pid(), lockErr.Holder.PID)
+	_, err = os.Stat(lockPath)
+	assert.NoError(t, err)
+
+	assert.NoError(t, held.Unlock())
+	assert.NoError(t, client.AcquireTanzuConfigLockContext(context.Background()))
+	holder := readLockHolder(lockPath)
+	assert.NotNil(t, holder)
+	assert.Equal(t, os.Getpid(), holder.PID)
+	assert.NoError(t, client.ReleaseTanzuConfigLockE())
+	assert.Nil(t, readLockHolder(lockPath))
+}
+
+func TestLockOfExitedProcessIsReleased(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, LocalTanzuFileLock)
+
+	// The lock file still names a process which exited without releasing the lock,
+	// whose lock was released by the operating system
+	cmd := exec.Command("go", "version")
+	assert.NoError(t, cmd.Run())
+	host, err := os.Hostname()
+	assert.NoError(t, err)
+	data, err := json.Marshal(&LockHolder{PID: cmd.ProcessState.Pid(), Host: host, AcquiredAt: time.Now()})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(lockPath, data, 0o600))
+
+	client := NewClient(WithLocalDir(dir), WithLockTimeout(300*time.Millisecond))
+	assert.NoError(t, client.AcquireTanzuConfigLockContext(context.Background()))
+	holder := readLockHolder(lockPath)
+	assert.NotNil(t, holder)
+	assert.Equal(t, os.Getpid(), holder.PID)
+	assert.NoError(t, client.ReleaseTanzuConfigLockE())
+}
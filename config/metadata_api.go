@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -15,8 +16,13 @@ import (
 
 // GetMetadata retrieves Metadata
 func GetMetadata() (*configtypes.Metadata, error) {
+	return DefaultClient().GetMetadata()
+}
+
+// GetMetadata retrieves Metadata
+func (client *Client) GetMetadata() (*configtypes.Metadata, error) {
 	// Retrieve config metadata node
-	node, err := getMetadataNode()
+	node, err := client.getMetadataNode()
 	if err != nil {
 		return nil, err
 	}
@@ -25,8 +31,13 @@ func GetMetadata() (*configtypes.Metadata, error) {
 
 // GetConfigMetadata retrieves configMetadata
 func GetConfigMetadata() (*configtypes.ConfigMetadata, error) {
+	return DefaultClient().GetConfigMetadata()
+}
+
+// GetConfigMetadata retrieves configMetadata
+func (client *Client) GetConfigMetadata() (*configtypes.ConfigMetadata, error) {
 	// Retrieve config metadata node
-	node, err := getMetadataNode()
+	node, err := client.getMetadataNode()
 	if err != nil {
 		return nil, err
 	}
@@ -35,10 +46,17 @@ func GetConfigMetadata() (*configtypes.ConfigMetadata, error) {
 
 // GetConfigMetadataPatchStrategy retrieves patch strategies
 func GetConfigMetadataPatchStrategy() (map[string]string, error) {
+	return DefaultClient().GetConfigMetadataPatchStrategy()
+}
+
+// GetConfigMetadataPatchStrategy retrieves patch strategies
+func (client *Client) GetConfigMetadataPatchStrategy() (map[string]string, error) {
 	// Retrieve config metadata node
-	AcquireTanzuMetadataLock()
-	defer ReleaseTanzuMetadataLock()
-	node, err := getMetadataNodeNoLock()
+	if err := client.AcquireTanzuMetadataLockContext(context.Background()); err != nil {
+		return nil, err
+	}
+	defer client.ReleaseTanzuMetadataLock()
+	node, err := client.getMetadataNodeNoLock()
 	if err != nil {
 		return nil, err
 	}
@@ -47,10 +65,17 @@ func GetConfigMetadataPatchStrategy() (map[string]string, error) {
 
 // SetConfigMetadataPatchStrategy add or update patch strategy specified by key-value pair
 func SetConfigMetadataPatchStrategy(key, value string) error {
+	return DefaultClient().SetConfigMetadataPatchStrategy(key, value)
+}
+
+// SetConfigMetadataPatchStrategy add or update patch strategy specified by key-value pair
+func (client *Client) SetConfigMetadataPatchStrategy(key, value string) error {
 	// Retrieve config metadata node
-	AcquireTanzuMetadataLock()
-	defer ReleaseTanzuMetadataLock()
-	node, err := getMetadataNodeNoLock()
+	if err := client.AcquireTanzuMetadataLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuMetadataLock()
+	node, err := client.getMetadataNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -60,15 +85,22 @@ func SetConfigMetadataPatchStrategy(key, value string) error {
 	if err != nil {
 		return err
 	}
-	return persistConfigMetadata(node)
+	return client.persistConfigMetadata(node)
 }
 
 // SetConfigMetadataPatchStrategies add or update map of patch strategies
 func SetConfigMetadataPatchStrategies(patchStrategies map[string]string) error {
+	return DefaultClient().SetConfigMetadataPatchStrategies(patchStrategies)
+}
+
+// SetConfigMetadataPatchStrategies add or update map of patch strategies
+func (client *Client) SetConfigMetadataPatchStrategies(patchStrategies map[string]string) error {
 	// Retrieve config metadata node
-	AcquireTanzuMetadataLock()
-	defer ReleaseTanzuMetadataLock()
-	node, err := getMetadataNodeNoLock()
+	if err := client.AcquireTanzuMetadataLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuMetadataLock()
+	node, err := client.getMetadataNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -78,7 +110,7 @@ func SetConfigMetadataPatchStrategies(patchStrategies map[string]string) error {
 	if err != nil {
 		return err
 	}
-	return persistConfigMetadata(node)
+	return client.persistConfigMetadata(node)
 }
 
 func getConfigMetadata(node *yaml.Node) (*configtypes.ConfigMetadata, error) {
@@ -91,7 +123,7 @@ func getConfigMetadata(node *yaml.Node) (*configtypes.ConfigMetadata, error) {
 		return metadata.ConfigMetadata, nil
 	}
 
-	return nil, errors.New("config metadata not found")
+	return nil, notFound(ErrConfigMetadataNotFound, "", "config metadata not found")
 }
 
 func getMetadata(node *yaml.Node) (*configtypes.Metadata, error) {
@@ -111,7 +143,7 @@ func getConfigMetadataPatchStrategy(node *yaml.Node) (map[string]string, error)
 		metadata.ConfigMetadata.PatchStrategy != nil {
 		return metadata.ConfigMetadata.PatchStrategy, nil
 	}
-	return nil, errors.New("config metadata patch strategy not found")
+	return nil, notFound(ErrPatchStrategyNotFound, "", "config metadata patch strategy not found")
 }
 
 func setConfigMetadataPatchStrategies(node *yaml.Node, patchStrategies map[string]string) error {
@@ -7,7 +7,7 @@
 package config
 
 import (
-	"os"
+	"context"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
@@ -16,37 +16,35 @@ import (
 )
 
 // getMetadataNode retrieves the config from the local directory with lock
-func getMetadataNode() (*yaml.Node, error) {
+func (client *Client) getMetadataNode() (*yaml.Node, error) {
 	// Retrieve config metadata node
-	AcquireTanzuMetadataLock()
-	defer ReleaseTanzuMetadataLock()
-	return getMetadataNodeNoLock()
+	if err := client.AcquireTanzuMetadataLockContext(context.Background()); err != nil {
+		return nil, err
+	}
+	defer client.ReleaseTanzuMetadataLock()
+	return client.getMetadataNodeNoLock()
 }
 
 // getMetadataNodeNoLock retrieves the config from the local directory without acquiring the lock
-func getMetadataNodeNoLock() (*yaml.Node, error) {
-	cfgPath, err := CfgMetadataFilePath()
+func (client *Client) getMetadataNodeNoLock() (*yaml.Node, error) {
+	bytes, err := client.storage.Read(MetadataFile)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed getting config metadata path")
+		return nil, errors.Wrap(err, "failed reading config metadata")
 	}
-
-	bytes, err := os.ReadFile(cfgPath)
-	if err != nil || len(bytes) == 0 {
+	if len(bytes) == 0 {
 		node, err := newMetadataNode()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create new config metadata")
 		}
 		return node, nil
 	}
-	var node yaml.Node
-
-	err = yaml.Unmarshal(bytes, &node)
+	node, err := client.unmarshalNode(MetadataFile, bytes)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to construct struct from config metadata data")
+		return nil, configCorrupt(client.location(MetadataFile), errors.Wrap(err, "failed to construct struct from config metadata data"))
 	}
 	node.Content[0].Style = 0
 
-	return &node, nil
+	return node, nil
 }
 
 func newMetadataNode() (*yaml.Node, error) {
@@ -59,10 +57,6 @@ func newMetadataNode() (*yaml.Node, error) {
 	return node, nil
 }
 
-func persistConfigMetadata(node *yaml.Node) error {
-	path, err := CfgMetadataFilePath()
-	if err != nil {
-		return errors.Wrap(err, "could not find config metadata path")
-	}
-	return persistNode(node, WithCfgPath(path))
+func (client *Client) persistConfigMetadata(node *yaml.Node) error {
+	return client.persistNode(node, MetadataFile)
 }
@@ -15,7 +15,7 @@ import (
 func TestConfigMetadataNodeUpdateInParallel(t *testing.T) {
 	addPatchStrategy := func(key, value string) error {
 		// Get config metadata node
-		_, err := getMetadataNode()
+		_, err := DefaultClient().getMetadataNode()
 		if err != nil {
 			return err
 		}
@@ -27,7 +27,7 @@ func TestConfigMetadataNodeUpdateInParallel(t *testing.T) {
 		}
 
 		// Get config metadata node
-		_, err = getMetadataNode()
+		_, err = DefaultClient().getMetadataNode()
 		return err
 	}
 
@@ -54,7 +54,7 @@ func TestConfigMetadataNodeUpdateInParallel(t *testing.T) {
 			_ = group.Wait()
 
 			// Make sure that the configuration file is not corrupted
-			node, err := getMetadataNode()
+			node, err := DefaultClient().getMetadataNode()
 			assert.Nil(t, err)
 			// Make sure all expected patch strategies are added to the patchStrategy list
 			assert.Equal(t, parallelExecutionCounter, len(node.Content[0].Content[1].Content[1].Content)/2)
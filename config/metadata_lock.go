@@ -5,12 +5,9 @@
 package config
 
 import (
+	"context"
 	"fmt"
-	"path/filepath"
-	"sync"
 	"time"
-
-	"github.com/juju/fslock"
 )
 
 const (
@@ -19,49 +16,48 @@ const (
 	DefaultMetadataLockTimeout = 10 * time.Minute
 )
 
-var tanzuMetadataLockFile string
-
-// tanzuMetadataLock used as a static lock variable that stores fslock
-// This is used for interprocess locking of the config file
-var tanzuMetadataLock *fslock.Lock
-
-// mutexMetadata is used to handle the locking behavior between concurrent calls
-// within the existing process trying to acquire the lock
-var mutexMetadata sync.Mutex
-
 // AcquireTanzuMetadataLock tries to acquire lock to update tanzu config metadata file with timeout
 func AcquireTanzuMetadataLock() {
-	var err error
-
-	if tanzuMetadataLockFile == "" {
-		path, err := CfgMetadataFilePath()
-		if err != nil {
-			panic(fmt.Sprintf("cannot get config path while acquiring lock on tanzu config metadata file, reason: %v", err))
-		}
-		tanzuMetadataLockFile = filepath.Join(filepath.Dir(path), LocalTanzuMetadataFileLock)
-	}
+	DefaultClient().AcquireTanzuMetadataLock()
+}
 
-	// using fslock to handle interprocess locking
-	lock, err := getFileLockWithTimeOut(tanzuMetadataLockFile, DefaultMetadataLockTimeout)
-	if err != nil {
+// AcquireTanzuMetadataLock tries to acquire lock to update tanzu config metadata file with timeout
+func (client *Client) AcquireTanzuMetadataLock() {
+	if err := client.AcquireTanzuMetadataLockContext(context.Background()); err != nil {
 		panic(fmt.Sprintf("cannot acquire lock for tanzu config metadata file, reason: %v", err))
 	}
+}
 
-	// Lock the mutex to prevent concurrent calls to acquire and configure the tanzuMetadataLock
-	mutexMetadata.Lock()
-	tanzuMetadataLock = lock
+// AcquireTanzuMetadataLockContext acquires the lock of the config metadata file, waiting
+// until the context is done or the lock timeout expires
+func AcquireTanzuMetadataLockContext(ctx context.Context) error {
+	return DefaultClient().AcquireTanzuMetadataLockContext(ctx)
+}
+
+// AcquireTanzuMetadataLockContext acquires the lock of the config metadata file, waiting
+// until the context is done or the lock timeout expires
+func (client *Client) AcquireTanzuMetadataLockContext(ctx context.Context) error {
+	return client.lock(ctx, MetadataFile)
 }
 
 // ReleaseTanzuMetadataLock releases the lock if the tanzuMetadataLock was acquired
 func ReleaseTanzuMetadataLock() {
-	if tanzuMetadataLock == nil {
-		return
-	}
-	if errUnlock := tanzuMetadataLock.Unlock(); errUnlock != nil {
-		panic(fmt.Sprintf("cannot release lock for tanzu config metadata file, reason: %v", errUnlock))
+	DefaultClient().ReleaseTanzuMetadataLock()
+}
+
+// ReleaseTanzuMetadataLock releases the lock if the tanzuMetadataLock was acquired
+func (client *Client) ReleaseTanzuMetadataLock() {
+	if err := client.ReleaseTanzuMetadataLockE(); err != nil {
+		panic(fmt.Sprintf("cannot release lock for tanzu config metadata file, reason: %v", err))
 	}
+}
+
+// ReleaseTanzuMetadataLockE releases the lock of the config metadata file if acquired
+func ReleaseTanzuMetadataLockE() error {
+	return DefaultClient().ReleaseTanzuMetadataLockE()
+}
 
-	tanzuMetadataLock = nil
-	// Unlock the mutex to allow other concurrent calls to acquire and configure the tanzuMetadataLock
-	mutexMetadata.Unlock()
+// ReleaseTanzuMetadataLockE releases the lock of the config metadata file if acquired
+func (client *Client) ReleaseTanzuMetadataLockE() error {
+	return client.storage.Unlock(MetadataFile)
 }
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -18,8 +19,13 @@ const (
 
 // GetConfigMetadataSettings retrieves feature flags
 func GetConfigMetadataSettings() (map[string]string, error) {
+	return DefaultClient().GetConfigMetadataSettings()
+}
+
+// GetConfigMetadataSettings retrieves feature flags
+func (client *Client) GetConfigMetadataSettings() (map[string]string, error) {
 	// Retrieve Metadata config node
-	node, err := getMetadataNode()
+	node, err := client.getMetadataNode()
 	if err != nil {
 		return nil, err
 	}
@@ -28,8 +34,12 @@ func GetConfigMetadataSettings() (map[string]string, error) {
 }
 
 func GetConfigMetadataSetting(key string) (string, error) {
+	return DefaultClient().GetConfigMetadataSetting(key)
+}
+
+func (client *Client) GetConfigMetadataSetting(key string) (string, error) {
 	// Retrieve Metadata config node
-	node, err := getMetadataNode()
+	node, err := client.getMetadataNode()
 	if err != nil {
 		return "", err
 	}
@@ -39,7 +49,12 @@ func GetConfigMetadataSetting(key string) (string, error) {
 
 // IsConfigMetadataSettingsEnabled checks and returns whether specific plugin and key is true
 func IsConfigMetadataSettingsEnabled(key string) (bool, error) {
-	node, err := getMetadataNode()
+	return DefaultClient().IsConfigMetadataSettingsEnabled(key)
+}
+
+// IsConfigMetadataSettingsEnabled checks and returns whether specific plugin and key is true
+func (client *Client) IsConfigMetadataSettingsEnabled(key string) (bool, error) {
+	node, err := client.getMetadataNode()
 	if err != nil {
 		return false, err
 	}
@@ -52,15 +67,37 @@ func IsConfigMetadataSettingsEnabled(key string) (bool, error) {
 
 // UseUnifiedConfig checks useUnifiedConfig feature flag
 func UseUnifiedConfig() (bool, error) {
-	return IsConfigMetadataSettingsEnabled(SettingUseUnifiedConfig)
+	return DefaultClient().UseUnifiedConfig()
+}
+
+// UseUnifiedConfig checks useUnifiedConfig feature flag
+func (client *Client) UseUnifiedConfig() (bool, error) {
+	return client.IsConfigMetadataSettingsEnabled(SettingUseUnifiedConfig)
+}
+
+// useUnifiedConfig checks useUnifiedConfig feature flag, which is disabled when
+// the setting is not set
+func (client *Client) useUnifiedConfig() (bool, error) {
+	enabled, err := client.UseUnifiedConfig()
+	if errors.Is(err, ErrConfigMetadataSettingNotFound) {
+		return false, nil
+	}
+	return enabled, err
 }
 
 // DeleteConfigMetadataSetting delete the env entry of specified key
 func DeleteConfigMetadataSetting(key string) error {
+	return DefaultClient().DeleteConfigMetadataSetting(key)
+}
+
+// DeleteConfigMetadataSetting delete the env entry of specified key
+func (client *Client) DeleteConfigMetadataSetting(key string) error {
 	// Retrieve config metadata node
-	AcquireTanzuMetadataLock()
-	defer ReleaseTanzuMetadataLock()
-	node, err := getMetadataNodeNoLock()
+	if err := client.AcquireTanzuMetadataLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuMetadataLock()
+	node, err := client.getMetadataNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -70,15 +107,22 @@ func DeleteConfigMetadataSetting(key string) error {
 		return err
 	}
 
-	return persistConfigMetadata(node)
+	return client.persistConfigMetadata(node)
 }
 
 // SetConfigMetadataSetting add or update a env key and value
 func SetConfigMetadataSetting(key, value string) (err error) {
+	return DefaultClient().SetConfigMetadataSetting(key, value)
+}
+
+// SetConfigMetadataSetting add or update a env key and value
+func (client *Client) SetConfigMetadataSetting(key, value string) (err error) {
 	// Retrieve config metadata node
-	AcquireTanzuMetadataLock()
-	defer ReleaseTanzuMetadataLock()
-	node, err := getMetadataNodeNoLock()
+	if err = client.AcquireTanzuMetadataLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuMetadataLock()
+	node, err := client.getMetadataNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -86,7 +130,7 @@ func SetConfigMetadataSetting(key, value string) (err error) {
 	persist, err := setSetting(node, key, value)
 
 	if persist {
-		return persistConfigMetadata(node)
+		return client.persistConfigMetadata(node)
 	}
 
 	return err
@@ -112,13 +156,13 @@ func getSetting(node *yaml.Node, key string) (string, error) {
 
 	if cfgMetadata == nil || cfgMetadata.ConfigMetadata == nil ||
 		cfgMetadata.ConfigMetadata.Settings == nil {
-		return "", errors.New("not found")
+		return "", notFound(ErrConfigMetadataSettingNotFound, key, "not found")
 	}
 
 	if val, ok := cfgMetadata.ConfigMetadata.Settings[key]; ok {
 		return val, nil
 	}
-	return "", errors.New("not found")
+	return "", notFound(ErrConfigMetadataSettingNotFound, key, "not found")
 }
 
 func deleteSetting(node *yaml.Node, key string) (err error) {
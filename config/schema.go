@@ -0,0 +1,122 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect of the generated schemas
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is a JSON Schema, generated from the config types
+type jsonSchema struct {
+	Schema     string                 `json:"$schema,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Type       string                 `json:"type,omitempty"`
+	Format     string                 `json:"format,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Items      *jsonSchema            `json:"items,omitempty"`
+
+	// AdditionalProperties is false for objects with fixed properties, or
+	// the schema of the values of maps, or nil for free-form objects
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+
+	// goType is the type scalar values are decoded to
+	goType reflect.Type
+}
+
+// ClientConfigJSONSchema returns the JSON Schema of the client config files,
+// config.yaml and config-ng.yaml, generated from types.ClientConfig
+func ClientConfigJSONSchema() ([]byte, error) {
+	return json.MarshalIndent(clientConfigSchema(), "", "  ")
+}
+
+// MetadataJSONSchema returns the JSON Schema of the config metadata file,
+// generated from types.Metadata
+func MetadataJSONSchema() ([]byte, error) {
+	return json.MarshalIndent(metadataSchema(), "", "  ")
+}
+
+// clientConfigSchema returns the schema of the client config files
+func clientConfigSchema() *jsonSchema {
+	schema := schemaOf(reflect.TypeOf(configtypes.ClientConfig{}))
+	schema.Schema = jsonSchemaDraft
+	schema.Title = "ClientConfig"
+	// The keys of the kubernetes style resources found in config.yaml
+	schema.Properties[KeyAPIVersion] = schemaOf(reflect.TypeOf(""))
+	schema.Properties[KeyKind] = schemaOf(reflect.TypeOf(""))
+	schema.Properties[KeyMetadata] = &jsonSchema{Type: "object"}
+	return schema
+}
+
+// metadataSchema returns the schema of the config metadata file
+func metadataSchema() *jsonSchema {
+	schema := schemaOf(reflect.TypeOf(configtypes.Metadata{}))
+	schema.Schema = jsonSchemaDraft
+	schema.Title = "Metadata"
+	return schema
+}
+
+// schemaOf returns the schema of the values of a type, as stored in the YAML
+// config files
+func schemaOf(t reflect.Type) *jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return &jsonSchema{Type: "string", Format: "date-time", goType: t}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		schema := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}, AdditionalProperties: false}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if name := yamlFieldName(field); name != "" {
+				schema.Properties[name] = schemaOf(field.Type)
+			}
+		}
+		return schema
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: schemaOf(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: schemaOf(t.Elem())}
+	case reflect.String:
+		return &jsonSchema{Type: "string", goType: t}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean", goType: t}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer", goType: t}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number", goType: t}
+	}
+	return &jsonSchema{}
+}
+
+// yamlFieldName returns the key of a struct field in the YAML config files, or
+// an empty name if the field is not stored
+func yamlFieldName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	tag, ok := field.Tag.Lookup("yaml")
+	if !ok {
+		tag = field.Tag.Get("json")
+	}
+	name := strings.Split(tag, ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -17,8 +18,15 @@ import (
 //
 // Deprecated: This API is deprecated. Use GetContext instead.
 func GetServer(name string) (*configtypes.Server, error) {
+	return DefaultClient().GetServer(name)
+}
+
+// GetServer retrieves server by name
+//
+// Deprecated: This API is deprecated. Use GetContext instead.
+func (client *Client) GetServer(name string) (*configtypes.Server, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +37,14 @@ func GetServer(name string) (*configtypes.Server, error) {
 //
 // Deprecated: This API is deprecated. Use ContextExists instead.
 func ServerExists(name string) (bool, error) {
-	exists, _ := GetServer(name)
+	return DefaultClient().ServerExists(name)
+}
+
+// ServerExists checks if server by specified name is present in config
+//
+// Deprecated: This API is deprecated. Use ContextExists instead.
+func (client *Client) ServerExists(name string) (bool, error) {
+	exists, _ := client.GetServer(name)
 	return exists != nil, nil
 }
 
@@ -37,8 +52,15 @@ func ServerExists(name string) (bool, error) {
 //
 // Deprecated: This API is deprecated. Use GetCurrentContext instead.
 func GetCurrentServer() (*configtypes.Server, error) {
+	return DefaultClient().GetCurrentServer()
+}
+
+// GetCurrentServer retrieves the current server
+//
+// Deprecated: This API is deprecated. Use GetCurrentContext instead.
+func (client *Client) GetCurrentServer() (*configtypes.Server, error) {
 	// Retrieve client config node
-	node, err := getClientConfigNode()
+	node, err := client.getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
@@ -49,10 +71,19 @@ func GetCurrentServer() (*configtypes.Server, error) {
 //
 // Deprecated: This API is deprecated. Use SetCurrentContext instead.
 func SetCurrentServer(name string) error {
+	return DefaultClient().SetCurrentServer(name)
+}
+
+// SetCurrentServer add or update current server
+//
+// Deprecated: This API is deprecated. Use SetCurrentContext instead.
+func (client *Client) SetCurrentServer(name string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -65,7 +96,7 @@ func SetCurrentServer(name string) error {
 		return err
 	}
 	if persist {
-		err = persistConfig(node)
+		err = client.persistConfig(node)
 		if err != nil {
 			return err
 		}
@@ -77,7 +108,7 @@ func SetCurrentServer(name string) error {
 		return err
 	}
 	if persist {
-		err = persistConfig(node)
+		err = client.persistConfig(node)
 		if err != nil {
 			return err
 		}
@@ -89,10 +120,19 @@ func SetCurrentServer(name string) error {
 //
 // Deprecated: This API is deprecated. Use RemoveCurrentContext instead.
 func RemoveCurrentServer(name string) error {
+	return DefaultClient().RemoveCurrentServer(name)
+}
+
+// RemoveCurrentServer removes the current server if server exists by specified name
+//
+// Deprecated: This API is deprecated. Use RemoveCurrentContext instead.
+func (client *Client) RemoveCurrentServer(name string) error {
 	// Retrieve client config node
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -114,40 +154,63 @@ func RemoveCurrentServer(name string) error {
 	if err != nil {
 		return err
 	}
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 // PutServer add or update server and currentServer
 //
 // Deprecated: This API is deprecated. Use AddContext or SetContext instead.
 func PutServer(s *configtypes.Server, setCurrent bool) error {
-	return SetServer(s, setCurrent)
+	return DefaultClient().PutServer(s, setCurrent)
+}
+
+// PutServer add or update server and currentServer
+//
+// Deprecated: This API is deprecated. Use AddContext or SetContext instead.
+func (client *Client) PutServer(s *configtypes.Server, setCurrent bool) error {
+	return client.SetServer(s, setCurrent)
 }
 
 // AddServer add or update server and currentServer
 //
 // Deprecated: This API is deprecated. Use AddContext or SetContext instead.
 func AddServer(s *configtypes.Server, setCurrent bool) error {
-	return SetServer(s, setCurrent)
+	return DefaultClient().AddServer(s, setCurrent)
+}
+
+// AddServer add or update server and currentServer
+//
+// Deprecated: This API is deprecated. Use AddContext or SetContext instead.
+func (client *Client) AddServer(s *configtypes.Server, setCurrent bool) error {
+	return client.SetServer(s, setCurrent)
 }
 
 // SetServer add or update server and currentServer
 //
 // Deprecated: This API is deprecated. Use AddContext or SetContext instead.
 func SetServer(s *configtypes.Server, setCurrent bool) error {
+	return DefaultClient().SetServer(s, setCurrent)
+}
+
+// SetServer add or update server and currentServer
+//
+// Deprecated: This API is deprecated. Use AddContext or SetContext instead.
+func (client *Client) SetServer(s *configtypes.Server, setCurrent bool) error {
 	// Acquire tanzu config lock
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
-	persist, err := setServer(node, s)
+	persist, err := client.setServer(node, s)
 	if err != nil {
 		return err
 	}
 	if persist {
-		err = persistConfig(node)
+		err = client.persistConfig(node)
 		if err != nil {
 			return err
 		}
@@ -158,14 +221,14 @@ func SetServer(s *configtypes.Server, setCurrent bool) error {
 			return err
 		}
 		if persist {
-			err = persistConfig(node)
+			err = client.persistConfig(node)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
-	err = frontFillContexts(s, setCurrent, node)
+	err = client.frontFillContexts(s, setCurrent, node)
 	if err != nil {
 		return err
 	}
@@ -173,15 +236,15 @@ func SetServer(s *configtypes.Server, setCurrent bool) error {
 	return nil
 }
 
-func frontFillContexts(s *configtypes.Server, setCurrent bool, node *yaml.Node) error {
+func (client *Client) frontFillContexts(s *configtypes.Server, setCurrent bool, node *yaml.Node) error {
 	// Front fill Context and CurrentContext
 	c := convertServerToContext(s)
-	persist, err := setContext(node, c)
+	persist, err := client.setContext(node, c)
 	if err != nil {
 		return err
 	}
 	if persist {
-		err = persistConfig(node)
+		err = client.persistConfig(node)
 		if err != nil {
 			return err
 		}
@@ -192,7 +255,7 @@ func frontFillContexts(s *configtypes.Server, setCurrent bool, node *yaml.Node)
 			return err
 		}
 		if persist {
-			err = persistConfig(node)
+			err = client.persistConfig(node)
 			if err != nil {
 				return err
 			}
@@ -205,16 +268,32 @@ func frontFillContexts(s *configtypes.Server, setCurrent bool, node *yaml.Node)
 //
 // Deprecated: This API is deprecated. Use DeleteContext instead.
 func DeleteServer(name string) error {
-	return RemoveServer(name)
+	return DefaultClient().DeleteServer(name)
+}
+
+// DeleteServer deletes the server specified by name
+//
+// Deprecated: This API is deprecated. Use DeleteContext instead.
+func (client *Client) DeleteServer(name string) error {
+	return client.RemoveServer(name)
 }
 
 // RemoveServer removed the server by name
 //
 // Deprecated: This API is deprecated. Use DeleteContext instead.
 func RemoveServer(name string) error {
-	AcquireTanzuConfigLock()
-	defer ReleaseTanzuConfigLock()
-	node, err := getClientConfigNodeNoLock()
+	return DefaultClient().RemoveServer(name)
+}
+
+// RemoveServer removed the server by name
+//
+// Deprecated: This API is deprecated. Use DeleteContext instead.
+func (client *Client) RemoveServer(name string) error {
+	if err := client.AcquireTanzuConfigLockContext(context.Background()); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+	node, err := client.getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
@@ -243,7 +322,7 @@ func RemoveServer(name string) error {
 	if err != nil {
 		return err
 	}
-	return persistConfig(node)
+	return client.persistConfig(node)
 }
 
 func setCurrentServer(node *yaml.Node, name string) (persist bool, err error) {
@@ -281,7 +360,7 @@ func getServer(node *yaml.Node, name string) (*configtypes.Server, error) {
 			return server, nil
 		}
 	}
-	return nil, fmt.Errorf("could not find server %q", name)
+	return nil, notFound(ErrServerNotFound, name, "could not find server %q", name)
 }
 
 func getCurrentServer(node *yaml.Node) (s *configtypes.Server, err error) {
@@ -294,7 +373,7 @@ func getCurrentServer(node *yaml.Node) (s *configtypes.Server, err error) {
 			return server, nil
 		}
 	}
-	return s, fmt.Errorf("current server %q not found in tanzu config", cfg.CurrentServer)
+	return s, notFound(ErrCurrentServerNotFound, cfg.CurrentServer, "current server %q not found in tanzu config", cfg.CurrentServer)
 }
 
 func removeCurrentServer(node *yaml.Node, name string) error {
@@ -333,9 +412,9 @@ func removeServer(node *yaml.Node, name string) error {
 	return nil
 }
 
-func setServers(node *yaml.Node, servers []*configtypes.Server) error {
+func (client *Client) setServers(node *yaml.Node, servers []*configtypes.Server) error {
 	for _, server := range servers {
-		_, err := setServer(node, server)
+		_, err := client.setServer(node, server)
 		if err != nil {
 			return err
 		}
@@ -343,9 +422,9 @@ func setServers(node *yaml.Node, servers []*configtypes.Server) error {
 	return nil
 }
 
-func setServer(node *yaml.Node, s *configtypes.Server) (persist bool, err error) {
+func (client *Client) setServer(node *yaml.Node, s *configtypes.Server) (persist bool, err error) {
 	// Get Patch Strategies
-	patchStrategies, err := GetConfigMetadataPatchStrategy()
+	patchStrategies, err := client.GetConfigMetadataPatchStrategy()
 	if err != nil {
 		patchStrategies = make(map[string]string)
 	}
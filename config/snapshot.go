@@ -0,0 +1,343 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+const (
+	// SnapshotsDirName is the name of the directory the config snapshots are
+	// stored in, next to the config file
+	SnapshotsDirName = "snapshots"
+
+	// snapshotExtension is the extension of the snapshot files
+	snapshotExtension = ".yaml"
+
+	// snapshotTimeFormat is the format of the names of timestamped snapshots
+	snapshotTimeFormat = "20060102T150405.000000000Z"
+)
+
+// snapshotFiles are the config files captured in the snapshots
+var snapshotFiles = []ConfigFile{ClientConfigFile, ClientConfigNextGenFile, MetadataFile}
+
+// snapshotNameRegexp matches the valid snapshot names
+var snapshotNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Snapshot is a copy of the config files, config.yaml, config-ng.yaml and the
+// config metadata file, taken at a point in time
+type Snapshot struct {
+	// Name is the name of the snapshot
+	Name string `json:"name" yaml:"name"`
+
+	// CreatedAt is the time the snapshot was taken
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+
+	// Files holds the content of the config files which existed when the
+	// snapshot was taken
+	Files map[ConfigFile]string `json:"files,omitempty" yaml:"files,omitempty"`
+
+	// Corrupt is set by ListSnapshots for the snapshot files which cannot be
+	// read or parsed. CreatedAt is then the modification time of the file and
+	// Files is empty, the snapshot can only be deleted.
+	Corrupt bool `json:"-" yaml:"-"`
+}
+
+// SnapshotDiff is the difference of a config file between two snapshots
+type SnapshotDiff struct {
+	// File is the config file
+	File ConfigFile
+
+	// Diff is the unified diff of the content of the file
+	Diff string
+}
+
+// CreateSnapshot captures the current config files in a snapshot. A timestamped
+// name is used if name is empty.
+func CreateSnapshot(name string) (*Snapshot, error) {
+	return DefaultClient().CreateSnapshot(name)
+}
+
+// CreateSnapshot captures the current config files in a snapshot. A timestamped
+// name is used if name is empty.
+func (client *Client) CreateSnapshot(name string) (*Snapshot, error) {
+	createdAt := time.Now().UTC()
+	if name == "" {
+		name = createdAt.Format(snapshotTimeFormat)
+	}
+	path, err := client.snapshotPath(name)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileExists(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check snapshot existence")
+	}
+	if exists {
+		return nil, errors.Errorf("snapshot %q already exists", name)
+	}
+
+	files, err := client.readConfigFiles(snapshotFiles)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{Name: name, CreatedAt: createdAt, Files: files}
+	data, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal snapshot")
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Wrap(err, "could not make snapshots directory")
+	}
+	if err = writeFileAtomic(path, data, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write the snapshot to file")
+	}
+	return snapshot, nil
+}
+
+// GetSnapshot retrieves the snapshot by name
+func GetSnapshot(name string) (*Snapshot, error) {
+	return DefaultClient().GetSnapshot(name)
+}
+
+// GetSnapshot retrieves the snapshot by name
+func (client *Client) GetSnapshot(name string) (*Snapshot, error) {
+	path, err := client.snapshotPath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, notFound(ErrSnapshotNotFound, name, "snapshot %q not found", name)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot")
+	}
+	snapshot := &Snapshot{}
+	if err = yaml.Unmarshal(data, snapshot); err != nil {
+		return nil, configCorrupt(path, errors.Wrap(err, "failed to parse snapshot"))
+	}
+	return snapshot, nil
+}
+
+// ListSnapshots returns the snapshots, the oldest first. The snapshots which
+// cannot be read are returned marked as corrupt.
+func ListSnapshots() ([]*Snapshot, error) {
+	return DefaultClient().ListSnapshots()
+}
+
+// ListSnapshots returns the snapshots, the oldest first. The snapshots which
+// cannot be read are returned marked as corrupt.
+func (client *Client) ListSnapshots() ([]*Snapshot, error) {
+	dir, err := client.snapshotsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshots directory")
+	}
+
+	var snapshots []*Snapshot
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), snapshotExtension)
+		if entry.IsDir() || name == entry.Name() || !snapshotNameRegexp.MatchString(name) {
+			continue
+		}
+		snapshot, getErr := client.GetSnapshot(name)
+		if getErr != nil {
+			log.Warningf("The snapshot %q cannot be read: %v", name, getErr)
+			snapshot = &Snapshot{Name: name, Corrupt: true}
+			if info, infoErr := entry.Info(); infoErr == nil {
+				snapshot.CreatedAt = info.ModTime().UTC()
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// DiffSnapshots returns the differences of the config files between two
+// snapshots, or between a snapshot and the current config files if to is
+// empty. Files which are identical are omitted.
+func DiffSnapshots(from, to string) ([]SnapshotDiff, error) {
+	return DefaultClient().DiffSnapshots(from, to)
+}
+
+// DiffSnapshots returns the differences of the config files between two
+// snapshots, or between a snapshot and the current config files if to is
+// empty. Files which are identical are omitted.
+func (client *Client) DiffSnapshots(from, to string) ([]SnapshotDiff, error) {
+	fromSnapshot, err := client.GetSnapshot(from)
+	if err != nil {
+		return nil, err
+	}
+	toName := "current"
+	var toFiles map[ConfigFile]string
+	if to == "" {
+		toFiles, err = client.readConfigFiles(snapshotFiles)
+	} else {
+		var toSnapshot *Snapshot
+		toSnapshot, err = client.GetSnapshot(to)
+		if toSnapshot != nil {
+			toName, toFiles = to, toSnapshot.Files
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []SnapshotDiff
+	for _, file := range snapshotFiles {
+		before, after := fromSnapshot.Files[file], toFiles[file]
+		if before == after {
+			continue
+		}
+		diff := unifiedDiff(from+"/"+string(file), toName+"/"+string(file), before, after)
+		diffs = append(diffs, SnapshotDiff{File: file, Diff: diff})
+	}
+	return diffs, nil
+}
+
+// RestoreSnapshot replaces the config files with their content in the
+// snapshot. The config files which did not exist when the snapshot was taken
+// are removed.
+func RestoreSnapshot(name string) error {
+	return DefaultClient().RestoreSnapshot(name)
+}
+
+// RestoreSnapshot replaces the config files with their content in the
+// snapshot. The config files which did not exist when the snapshot was taken
+// are removed.
+func (client *Client) RestoreSnapshot(name string) error {
+	snapshot, err := client.GetSnapshot(name)
+	if err != nil {
+		return err
+	}
+	unlock, err := client.lockConfigFiles(context.Background())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = client.replaceFiles(snapshotFiles, func() error {
+		for _, file := range snapshotFiles {
+			var data []byte
+			if content, ok := snapshot.Files[file]; ok {
+				data = []byte(content)
+			}
+			if restoreErr := client.restoreFile(file, data); restoreErr != nil {
+				return errors.Wrapf(restoreErr, "failed to restore %s", client.location(file))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if content, ok := snapshot.Files[ClientConfigFile]; ok {
+		client.storeConfigToLegacyDir([]byte(content))
+	}
+	return nil
+}
+
+// DeleteSnapshot deletes the snapshot by name
+func DeleteSnapshot(name string) error {
+	return DefaultClient().DeleteSnapshot(name)
+}
+
+// DeleteSnapshot deletes the snapshot by name
+func (client *Client) DeleteSnapshot(name string) error {
+	path, err := client.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return notFound(ErrSnapshotNotFound, name, "snapshot %q not found", name)
+	}
+	return err
+}
+
+// PruneSnapshots deletes the oldest snapshots, keeping the specified number of
+// most recent snapshots, and returns the names of the deleted snapshots.
+// Corrupt snapshots are always deleted and not counted in the snapshots kept.
+func PruneSnapshots(keep int) ([]string, error) {
+	return DefaultClient().PruneSnapshots(keep)
+}
+
+// PruneSnapshots deletes the oldest snapshots, keeping the specified number of
+// most recent snapshots, and returns the names of the deleted snapshots.
+// Corrupt snapshots are always deleted and not counted in the snapshots kept.
+func (client *Client) PruneSnapshots(keep int) ([]string, error) {
+	if keep < 0 {
+		return nil, errors.Errorf("invalid number of snapshots to keep: %d", keep)
+	}
+	snapshots, err := client.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	valid := 0
+	for _, snapshot := range snapshots {
+		if !snapshot.Corrupt {
+			valid++
+		}
+	}
+	var pruned []string
+	for _, snapshot := range snapshots {
+		if !snapshot.Corrupt {
+			if valid <= keep {
+				continue
+			}
+			valid--
+		}
+		if err = client.DeleteSnapshot(snapshot.Name); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, snapshot.Name)
+	}
+	return pruned, nil
+}
+
+// snapshotsDir returns the directory the snapshots are stored in
+func (client *Client) snapshotsDir() (string, error) {
+	if client.snapshotDir != "" {
+		return client.snapshotDir, nil
+	}
+	path, err := client.filePath(ClientConfigFile)
+	if err != nil {
+		return "", errors.Wrap(err, "the snapshot directory must be set with WithSnapshotDir")
+	}
+	return filepath.Join(filepath.Dir(path), SnapshotsDirName), nil
+}
+
+// snapshotPath returns the path of the snapshot file
+func (client *Client) snapshotPath(name string) (string, error) {
+	if !snapshotNameRegexp.MatchString(name) {
+		return "", errors.Errorf("invalid snapshot name %q", name)
+	}
+	dir, err := client.snapshotsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+snapshotExtension), nil
+}
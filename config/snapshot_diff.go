@@ -0,0 +1,221 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines around the changes of a diff
+const diffContextLines = 3
+
+// diffOp is a line of a diff, unchanged (' '), removed ('-') or added ('+')
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns the unified diff of the lines of two texts
+func unifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(ops); {
+		// Find the next change
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// Extend the hunk up to the change followed by more unchanged lines
+		// than the context of two hunks
+		end := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*diffContextLines {
+				break
+			}
+		}
+		hunkStart, hunkEnd := first-diffContextLines, end+diffContextLines
+		if hunkStart < start {
+			hunkStart = start
+		}
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+
+		writeDiffHunk(&sb, ops, hunkStart, hunkEnd)
+		start = hunkEnd
+	}
+	return sb.String()
+}
+
+// writeDiffHunk writes the lines of a diff between start and end, with the
+// header giving their position in both texts
+func writeDiffHunk(sb *strings.Builder, ops []diffOp, start, end int) {
+	fromLine, toLine := 0, 0
+	for _, op := range ops[:start] {
+		if op.kind != '+' {
+			fromLine++
+		}
+		if op.kind != '-' {
+			toLine++
+		}
+	}
+	fromCount, toCount := 0, 0
+	for _, op := range ops[start:end] {
+		if op.kind != '+' {
+			fromCount++
+		}
+		if op.kind != '-' {
+			toCount++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+	for _, op := range ops[start:end] {
+		fmt.Fprintf(sb, "%c%s\n", op.kind, op.line)
+	}
+}
+
+// hunkRange returns the range of the lines of a hunk, starting after the
+// specified number of lines
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// diffLines returns the shortest edit turning the lines of from into the lines
+// of to. It uses the linear space variant of the Myers algorithm, so the
+// memory used grows with the number of lines rather than their square.
+func diffLines(from, to []string) []diffOp {
+	return appendDiff(make([]diffOp, 0, len(from)+len(to)), from, to)
+}
+
+// appendDiff appends the shortest edit turning from into to to the ops
+func appendDiff(ops []diffOp, from, to []string) []diffOp {
+	// Trim the common prefix and suffix
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	for _, line := range from[:prefix] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+
+	middleFrom, middleTo := from[prefix:len(from)-suffix], to[prefix:len(to)-suffix]
+	switch {
+	case len(middleFrom) == 0 || len(middleTo) == 0:
+		for _, line := range middleFrom {
+			ops = append(ops, diffOp{kind: '-', line: line})
+		}
+		for _, line := range middleTo {
+			ops = append(ops, diffOp{kind: '+', line: line})
+		}
+	default:
+		x, y := middleSnake(middleFrom, middleTo)
+		ops = appendDiff(ops, middleFrom[:x], middleTo[:y])
+		ops = appendDiff(ops, middleFrom[x:], middleTo[y:])
+	}
+
+	for _, line := range from[len(from)-suffix:] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	return ops
+}
+
+// middleSnake returns a point on a shortest edit turning from into to, splitting
+// it in two edits of about the same length. The forward and reverse searches
+// only keep the furthest point reached on each diagonal.
+func middleSnake(from, to []string) (int, int) {
+	maxD := (len(from) + len(to) + 1) / 2
+	offset := maxD
+	forward := make([]int, 2*maxD+1)
+	reverse := make([]int, 2*maxD+1)
+	for i := range forward {
+		forward[i], reverse[i] = -1, -1
+	}
+	forward[offset+1], reverse[offset+1] = 0, 0
+
+	delta := len(from) - len(to)
+	// The paths meet in the forward search if delta is odd
+	meetForward := delta%2 != 0
+	// The diagonals out of bounds on each side are skipped
+	forwardStart, forwardEnd, reverseStart, reverseEnd := 0, 0, 0, 0
+	for d := 0; d < maxD; d++ {
+		for k := -d + forwardStart; k <= d-forwardEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < len(from) && y < len(to) && from[x] == to[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			switch {
+			case x > len(from):
+				forwardEnd += 2
+			case y > len(to):
+				forwardStart += 2
+			case meetForward:
+				i := offset + delta - k
+				if i >= 0 && i < len(reverse) && reverse[i] != -1 && x >= len(from)-reverse[i] {
+					return x, y
+				}
+			}
+		}
+
+		for k := -d + reverseStart; k <= d-reverseEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && reverse[offset+k-1] < reverse[offset+k+1]) {
+				x = reverse[offset+k+1]
+			} else {
+				x = reverse[offset+k-1] + 1
+			}
+			y := x - k
+			for x < len(from) && y < len(to) && from[len(from)-1-x] == to[len(to)-1-y] {
+				x++
+				y++
+			}
+			reverse[offset+k] = x
+			switch {
+			case x > len(from):
+				reverseEnd += 2
+			case y > len(to):
+				reverseStart += 2
+			case !meetForward:
+				i := offset + delta - k
+				if i >= 0 && i < len(forward) && forward[i] != -1 && forward[i] >= len(from)-x {
+					return forward[i], forward[i] - (i - offset)
+				}
+			}
+		}
+	}
+	// Only reached if from and to have no line in common
+	return len(from), 0
+}
+
+// splitLines splits a text in lines, without the line terminators
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(WithLocalDir(dir))
+	assert.NoError(t, client.SetEnv("key", "before"))
+	assert.NoError(t, client.SetConfigMetadataSetting("useUnifiedConfig", "false"))
+
+	snapshot, err := client.CreateSnapshot("before-upgrade")
+	assert.NoError(t, err)
+	assert.Equal(t, "before-upgrade", snapshot.Name)
+	assert.ElementsMatch(t, []ConfigFile{ClientConfigFile, MetadataFile, ClientConfigNextGenFile}, keys(snapshot.Files))
+	_, err = os.Stat(filepath.Join(dir, SnapshotsDirName, "before-upgrade.yaml"))
+	assert.NoError(t, err)
+
+	_, err = client.CreateSnapshot("before-upgrade")
+	assert.EqualError(t, err, `snapshot "before-upgrade" already exists`)
+
+	// Risky operations
+	assert.NoError(t, client.SetEnv("key", "after"))
+	assert.NoError(t, client.DeleteConfigMetadataSetting("useUnifiedConfig"))
+	timestamped, err := client.CreateSnapshot("")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^\d{8}T\d{6}\.\d{9}Z$`, timestamped.Name)
+
+	snapshots, err := client.ListSnapshots()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+	assert.Equal(t, "before-upgrade", snapshots[0].Name)
+	assert.Equal(t, timestamped.Name, snapshots[1].Name)
+
+	diffs, err := client.DiffSnapshots("before-upgrade", timestamped.Name)
+	assert.NoError(t, err)
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, ClientConfigFile, diffs[0].File)
+	assert.Contains(t, diffs[0].Diff, "-        key: before\n+        key: after\n")
+	assert.Equal(t, MetadataFile, diffs[1].File)
+
+	diffs, err = client.DiffSnapshots(timestamped.Name, "")
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	assert.NoError(t, client.RestoreSnapshot("before-upgrade"))
+	val, err := client.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "before", val)
+	setting, err := client.GetConfigMetadataSetting("useUnifiedConfig")
+	assert.NoError(t, err)
+	assert.Equal(t, "false", setting)
+
+	pruned, err := client.PruneSnapshots(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"before-upgrade"}, pruned)
+	_, err = client.GetSnapshot("before-upgrade")
+	assert.ErrorIs(t, err, ErrSnapshotNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, client.DeleteSnapshot(timestamped.Name))
+	snapshots, err = client.ListSnapshots()
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func TestRestoreSnapshotRemovesNewFiles(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(nil)), WithSnapshotDir(t.TempDir()))
+	_, err := client.CreateSnapshot("empty")
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.SetEnv("key", "value"))
+	assert.NoError(t, client.RestoreSnapshot("empty"))
+	for _, file := range snapshotFiles {
+		data, err := client.Storage().Read(file)
+		assert.NoError(t, err)
+		assert.Nil(t, data, file)
+	}
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(nil)))
+	_, err := client.CreateSnapshot("test")
+	assert.ErrorContains(t, err, "the snapshot directory must be set with WithSnapshotDir")
+
+	client = NewClient(WithLocalDir(t.TempDir()))
+	_, err = client.CreateSnapshot("../test")
+	assert.EqualError(t, err, `invalid snapshot name "../test"`)
+	assert.ErrorIs(t, client.RestoreSnapshot("missing"), ErrSnapshotNotFound)
+	assert.ErrorIs(t, client.DeleteSnapshot("missing"), ErrSnapshotNotFound)
+	_, err = client.PruneSnapshots(-1)
+	assert.Error(t, err)
+}
+
+func TestCorruptSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(WithStorage(NewMemoryStorage(nil)), WithSnapshotDir(dir))
+	_, err := client.CreateSnapshot("first")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.yaml"), []byte("createdAt: [\n"), 0600))
+	_, err = client.CreateSnapshot("second")
+	assert.NoError(t, err)
+
+	snapshots, err := client.ListSnapshots()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 3)
+	for _, snapshot := range snapshots {
+		assert.Equal(t, snapshot.Name == "corrupt", snapshot.Corrupt, snapshot.Name)
+	}
+
+	pruned, err := client.PruneSnapshots(1)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"first", "corrupt"}, pruned)
+	snapshots, err = client.ListSnapshots()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, "second", snapshots[0].Name)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	assert.Equal(t, `--- from
++++ to
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -10,3 +10,4 @@
+ j
+ k
+ l
++m
+`, unifiedDiff("from", "to", from, to))
+
+	assert.Equal(t, "--- from\n+++ to\n@@ -0,0 +1,1 @@\n+a\n", unifiedDiff("from", "to", "", "a\n"))
+}
+
+func TestDiffLinesShortestEdit(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rnd.Intn(12))
+		// Few distinct lines, so that they have lines in common
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+	for n := 0; n < 500; n++ {
+		from, to := randomLines(), randomLines()
+		gotFrom, gotTo := []string{}, []string{}
+		edits := 0
+		for _, op := range diffLines(from, to) {
+			if op.kind != '+' {
+				gotFrom = append(gotFrom, op.line)
+			}
+			if op.kind != '-' {
+				gotTo = append(gotTo, op.line)
+			}
+			if op.kind != ' ' {
+				edits++
+			}
+		}
+		assert.Equal(t, from, gotFrom)
+		assert.Equal(t, to, gotTo)
+		assert.Equal(t, len(from)+len(to)-2*commonLines(from, to), edits, "%v %v", from, to)
+	}
+}
+
+// commonLines returns the length of the longest common subsequence of the lines
+func commonLines(from, to []string) int {
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			switch {
+			case from[i] == to[j]:
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	return common[0][0]
+}
+
+func keys(files map[ConfigFile]string) []ConfigFile {
+	var result []ConfigFile
+	for file := range files {
+		result = append(result, file)
+	}
+	return result
+}
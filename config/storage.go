@@ -0,0 +1,53 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+)
+
+// ConfigFile identifies one of the files the configuration is stored in
+type ConfigFile string
+
+const (
+	// ClientConfigFile is the config.yaml file
+	ClientConfigFile ConfigFile = "config"
+	// ClientConfigNextGenFile is the config-ng.yaml file
+	ClientConfigNextGenFile ConfigFile = "config-ng"
+	// MetadataFile is the config metadata file
+	MetadataFile ConfigFile = "config-metadata"
+	// LegacyClientConfigFile is the config.yaml file of the legacy config directory,
+	// written for backward compatibility
+	LegacyClientConfigFile ConfigFile = "legacy-config"
+)
+
+// Storage is the backend storing the config files.
+//
+// Storage implementations must be safe for concurrent use.
+type Storage interface {
+	// Read returns the content of the file, or an empty content if the file does not exist.
+	Read(file ConfigFile) ([]byte, error)
+
+	// Write replaces the content of the file.
+	Write(file ConfigFile, data []byte) error
+
+	// Remove deletes the file.
+	Remove(file ConfigFile) error
+
+	// Lock acquires the exclusive lock of the file, waiting for the lock to be released
+	// by other goroutines or processes until the context is done. A *LockError is
+	// returned if the lock is not acquired.
+	Lock(ctx context.Context, file ConfigFile) error
+
+	// Unlock releases the lock of the file. Unlocking a file which is not locked does nothing.
+	Unlock(file ConfigFile) error
+}
+
+// BackupStorage is implemented by the storages keeping a backup of the config
+// files, used to recover the files which cannot be parsed.
+type BackupStorage interface {
+	// ReadBackup returns the content of the backup of the file, or an empty
+	// content if the file has no backup.
+	ReadBackup(file ConfigFile) ([]byte, error)
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/fslock"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// filesystemStorage stores the config files in the local tanzu directory, or
+// at the paths set with the TANZU_CONFIG, TANZU_CONFIG_NEXT_GEN and
+// TANZU_CONFIG_METADATA environment variables.
+type filesystemStorage struct {
+	paths map[ConfigFile]func() (string, error)
+	locks map[ConfigFile]*fileLock
+}
+
+// fileLock is the lock of a config file
+type fileLock struct {
+	// name is the name of the lock file, stored next to the config file
+	name string
+
+	// path is the path of the lock file, set when first locked
+	path string
+
+	// mutex guards the path
+	mutex sync.Mutex
+
+	// lock is used for interprocess locking of the config file
+	lock *fslock.Lock
+
+	// held holds a value while the lock is held, to handle the locking behavior
+	// between concurrent calls within the existing process trying to acquire the lock
+	held chan struct{}
+}
+
+// NewFilesystemStorage returns the storage of the config files in the filesystem
+func NewFilesystemStorage() Storage {
+	return newFilesystemStorage(map[ConfigFile]func() (string, error){
+		ClientConfigFile:        ClientConfigPath,
+		ClientConfigNextGenFile: ClientConfigNextGenPath,
+		MetadataFile:            CfgMetadataFilePath,
+		LegacyClientConfigFile:  legacyConfigPath,
+	})
+}
+
+// newFilesystemStorageInDir returns the storage of the config files in the
+// specified directory, without legacy config file
+func newFilesystemStorageInDir(dir string) Storage {
+	inDir := func(name string) func() (string, error) {
+		return func() (string, error) {
+			return filepath.Join(dir, name), nil
+		}
+	}
+	return newFilesystemStorage(map[ConfigFile]func() (string, error){
+		ClientConfigFile:        inDir(ConfigName),
+		ClientConfigNextGenFile: inDir(CfgNextGenName),
+		MetadataFile:            inDir(CfgMetadataName),
+	})
+}
+
+func newFilesystemStorage(paths map[ConfigFile]func() (string, error)) *filesystemStorage {
+	return &filesystemStorage{
+		paths: paths,
+		locks: map[ConfigFile]*fileLock{
+			ClientConfigFile:        newFileLock(LocalTanzuFileLock),
+			ClientConfigNextGenFile: newFileLock(LocalTanzuConfigNextGenFileLock),
+			MetadataFile:            newFileLock(LocalTanzuMetadataFileLock),
+		},
+	}
+}
+
+func newFileLock(name string) *fileLock {
+	return &fileLock{name: name, held: make(chan struct{}, 1)}
+}
+
+func (s *filesystemStorage) path(file ConfigFile) (string, error) {
+	path, ok := s.paths[file]
+	if !ok {
+		return "", errors.Errorf("unknown config file %q", file)
+	}
+	return path()
+}
+
+func (s *filesystemStorage) Read(file ConfigFile) ([]byte, error) {
+	if _, ok := s.paths[file]; !ok && file == LegacyClientConfigFile {
+		return nil, nil
+	}
+	path, err := s.path(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (s *filesystemStorage) Write(file ConfigFile, data []byte) error {
+	if _, ok := s.paths[file]; !ok && file == LegacyClientConfigFile {
+		// The legacy config is not written by storages without legacy location
+		return nil
+	}
+	path, err := s.path(file)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	dirExists, err := fileExists(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to check config path existence")
+	}
+	if !dirExists {
+		if file == LegacyClientConfigFile {
+			// Assume user has migrated and ignore writing to legacy location if that dir does not exist.
+			return nil
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.Wrap(err, "could not make local tanzu directory")
+		}
+	}
+
+	if err := backupConfigFile(path, data); err != nil {
+		return errors.Wrap(err, "failed to back up the config file")
+	}
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write the config to file")
+	}
+	return nil
+}
+
+// ReadBackup returns the content of the backup of the file, holding the last
+// valid version of the file before it was replaced
+func (s *filesystemStorage) ReadBackup(file ConfigFile) ([]byte, error) {
+	if _, ok := s.paths[file]; !ok && file == LegacyClientConfigFile {
+		return nil, nil
+	}
+	path, err := s.path(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path + backupSuffix)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// backupConfigFile keeps the current content of the config file in its backup
+// file before the file is replaced with data. The backup is only replaced
+// when the current content changes and can be parsed, so that the backup
+// always holds the last valid version of the file.
+func backupConfigFile(path string, data []byte) error {
+	current, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(current, data) || !isYAMLDocument(current) {
+		return nil
+	}
+	return writeFileAtomic(path+backupSuffix, current, 0644)
+}
+
+// isYAMLDocument checks whether data can be parsed as a non-empty YAML document
+func isYAMLDocument(data []byte) bool {
+	var node yaml.Node
+	return yaml.Unmarshal(data, &node) == nil && len(node.Content) > 0
+}
+
+func (s *filesystemStorage) Remove(file ConfigFile) error {
+	if _, ok := s.paths[file]; !ok && file == LegacyClientConfigFile {
+		return nil
+	}
+	path, err := s.path(file)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+func (s *filesystemStorage) Lock(ctx context.Context, file ConfigFile) error {
+	fl, ok := s.locks[file]
+	if !ok {
+		return nil
+	}
+	lockPath, err := fl.lockPath(func() (string, error) { return s.path(file) })
+	if err != nil {
+		return errors.Wrap(err, "cannot get config path while acquiring lock")
+	}
+
+	// Wait for the concurrent calls within the existing process to release the lock
+	select {
+	case fl.held <- struct{}{}:
+	case <-ctx.Done():
+		return &LockError{Path: lockPath, Err: ctx.Err()}
+	}
+
+	// using fslock to handle interprocess locking
+	lock, err := acquireFileLock(ctx, lockPath)
+	if err != nil {
+		<-fl.held
+		return err
+	}
+	fl.lock = lock
+	return nil
+}
+
+func (s *filesystemStorage) Unlock(file ConfigFile) error {
+	fl, ok := s.locks[file]
+	if !ok || fl.lock == nil {
+		return nil
+	}
+	if err := releaseFileLock(fl.lock, fl.path); err != nil {
+		return err
+	}
+
+	fl.lock = nil
+	// Allow other concurrent calls to acquire the lock
+	<-fl.held
+	return nil
+}
+
+// lockPath returns the path of the lock file, next to the config file
+func (fl *fileLock) lockPath(configPath func() (string, error)) (string, error) {
+	fl.mutex.Lock()
+	defer fl.mutex.Unlock()
+	if fl.path == "" {
+		path, err := configPath()
+		if err != nil {
+			return "", err
+		}
+		fl.path = filepath.Join(filepath.Dir(path), fl.name)
+	}
+	return fl.path, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// memoryStorage stores the config files in memory
+type memoryStorage struct {
+	mutex sync.Mutex
+	files map[ConfigFile][]byte
+	locks map[ConfigFile]chan struct{}
+}
+
+// NewMemoryStorage returns a storage keeping the config files in memory, for
+// embedders and tests using the config APIs without touching the filesystem.
+// The files are initialized with the given content, if any.
+func NewMemoryStorage(files map[ConfigFile][]byte) Storage {
+	s := &memoryStorage{
+		files: make(map[ConfigFile][]byte),
+		locks: make(map[ConfigFile]chan struct{}),
+	}
+	for file, data := range files {
+		s.files[file] = append([]byte(nil), data...)
+	}
+	return s
+}
+
+func (s *memoryStorage) Read(file ConfigFile) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]byte(nil), s.files[file]...), nil
+}
+
+func (s *memoryStorage) Write(file ConfigFile, data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.files[file] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *memoryStorage) Remove(file ConfigFile) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.files[file]; !ok {
+		return errors.Wrapf(os.ErrNotExist, "config file %q", file)
+	}
+	delete(s.files, file)
+	return nil
+}
+
+// lock returns the lock of the file, a channel holding a value while the file is locked
+func (s *memoryStorage) lock(file ConfigFile) chan struct{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	lock, ok := s.locks[file]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		s.locks[file] = lock
+	}
+	return lock
+}
+
+func (s *memoryStorage) Lock(ctx context.Context, file ConfigFile) error {
+	select {
+	case s.lock(file) <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return &LockError{Path: string(file), Err: ctx.Err()}
+	}
+}
+
+func (s *memoryStorage) Unlock(file ConfigFile) error {
+	select {
+	case <-s.lock(file):
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// useMemoryStorage sets an in-memory storage backend for the duration of the test
+func useMemoryStorage(t *testing.T, files map[ConfigFile][]byte) Storage {
+	previous := GetStorage()
+	storage := NewMemoryStorage(files)
+	SetStorage(storage)
+	t.Cleanup(func() {
+		SetStorage(previous)
+	})
+	return storage
+}
+
+func TestMemoryStorageConfigAPIs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	storage := useMemoryStorage(t, map[ConfigFile][]byte{
+		ClientConfigFile: []byte("clientOptions:\n  env:\n    existing: value\n"),
+	})
+
+	ctx := &configtypes.Context{
+		Name:   "test-ctx",
+		Target: configtypes.TargetK8s,
+		ClusterOpts: &configtypes.ClusterServer{
+			Endpoint: "test-endpoint",
+			Path:     "test-path",
+			Context:  "test-context",
+		},
+	}
+	assert.NoError(t, SetContext(ctx, true))
+	assert.NoError(t, SetEnv("key", "val"))
+	assert.NoError(t, SetFeature("global", "feature", "true"))
+
+	current, err := GetCurrentContext(configtypes.TargetK8s)
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, current)
+	envs, err := GetAllEnvs()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"existing": "value", "key": "val"}, envs)
+	enabled, err := IsFeatureEnabled("global", "feature")
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+
+	cfg, err := storage.Read(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(cfg), "key: val")
+	cfgNextGen, err := storage.Read(ClientConfigNextGenFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(cfgNextGen), "test-ctx")
+
+	entries, err := os.ReadDir(home)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, DeleteClientConfigNextGen())
+	assert.Error(t, DeleteClientConfigNextGen())
+}
+
+func TestMemoryStorageUnifiedConfig(t *testing.T) {
+	storage := useMemoryStorage(t, nil)
+
+	assert.NoError(t, SetConfigMetadataSetting(SettingUseUnifiedConfig, "true"))
+	assert.NoError(t, SetEnv("key", "val"))
+
+	cfg, err := storage.Read(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Empty(t, cfg)
+	cfgNextGen, err := storage.Read(ClientConfigNextGenFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(cfgNextGen), "key: val")
+}
+
+func TestMemoryStorageLock(t *testing.T) {
+	storage := NewMemoryStorage(nil)
+	assert.NoError(t, storage.Unlock(ClientConfigFile))
+	assert.NoError(t, storage.Lock(context.Background(), ClientConfigFile))
+	assert.NoError(t, storage.Lock(context.Background(), MetadataFile))
+
+	locked := make(chan struct{})
+	go func() {
+		_ = storage.Lock(context.Background(), ClientConfigFile)
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("lock acquired while held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.NoError(t, storage.Unlock(ClientConfigFile))
+	<-locked
+}
+
+func TestFilesystemStorage(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(EnvConfigKey, filepath.Join(dir, "nested", "config.yaml"))
+	t.Setenv("HOME", dir)
+	storage := NewFilesystemStorage()
+
+	data, err := storage.Read(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+
+	assert.NoError(t, storage.Write(ClientConfigFile, []byte("kind: ClientConfig\n")))
+	data, err = storage.Read(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: ClientConfig\n", string(data))
+
+	// The legacy config is only written if the legacy directory exists
+	assert.NoError(t, storage.Write(LegacyClientConfigFile, data))
+	_, err = os.Stat(filepath.Join(dir, legacyLocalDirName))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, storage.Lock(context.Background(), ClientConfigFile))
+	_, err = os.Stat(filepath.Join(dir, "nested", LocalTanzuFileLock))
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Unlock(ClientConfigFile))
+	assert.NoError(t, storage.Unlock(ClientConfigFile))
+
+	assert.NoError(t, storage.Remove(ClientConfigFile))
+	assert.Error(t, storage.Remove(ClientConfigFile))
+
+	assert.ErrorContains(t, storage.Write(ConfigFile("unknown"), nil), "unknown config file")
+}
+
+func TestFilesystemStorageWritesAtomicallyWithBackup(t *testing.T) {
+	dir := t.TempDir()
+	storage := newFilesystemStorageInDir(dir)
+	path := filepath.Join(dir, ConfigName)
+
+	backup, err := storage.(BackupStorage).ReadBackup(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Empty(t, backup)
+
+	assert.NoError(t, storage.Write(ClientConfigFile, []byte("kind: v1\n")))
+	assert.NoError(t, os.Chmod(path, 0600))
+	assert.NoError(t, storage.Write(ClientConfigFile, []byte("kind: v2\n")))
+	// Writing the same content keeps the backup of the previous version
+	assert.NoError(t, storage.Write(ClientConfigFile, []byte("kind: v2\n")))
+
+	data, err := storage.Read(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: v2\n", string(data))
+	backup, err = storage.(BackupStorage).ReadBackup(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: v1\n", string(backup))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// A corrupt file does not replace the backup of the last valid version
+	assert.NoError(t, os.WriteFile(path, []byte("kind: [v3\n"), 0600))
+	assert.NoError(t, storage.Write(ClientConfigFile, []byte("kind: v4\n")))
+	backup, err = storage.(BackupStorage).ReadBackup(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: v1\n", string(backup))
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{ConfigName, ConfigName + backupSuffix}, names)
+}
+
+func TestFilesystemStorageWritesThroughSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	storage := newFilesystemStorageInDir(dir)
+	path := filepath.Join(dir, ConfigName)
+	target := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(target, []byte("kind: v1\n"), 0600))
+	assert.NoError(t, os.Symlink(target, path))
+
+	assert.NoError(t, storage.Write(ClientConfigFile, []byte("kind: v2\n")))
+
+	info, err := os.Lstat(path)
+	assert.NoError(t, err)
+	assert.NotZero(t, info.Mode()&os.ModeSymlink)
+	data, err := os.ReadFile(target)
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: v2\n", string(data))
+}
+
+func TestCorruptConfigIsRecoveredFromBackup(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(WithLocalDir(dir))
+	assert.NoError(t, client.SetEnv("key", "first"))
+	assert.NoError(t, client.SetEnv("key", "second"))
+
+	path, err := client.ClientConfigPath()
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(path, []byte("clientOptions:\n  env: [\n"), 0644))
+
+	// The last valid version before the corruption is used
+	val, err := client.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", val)
+
+	assert.NoError(t, client.SetEnv("other", "value"))
+	val, err = client.GetEnv("other")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+
+	// Without a valid backup, the corruption is reported
+	assert.NoError(t, os.WriteFile(path, []byte("clientOptions:\n  env: [\n"), 0644))
+	assert.NoError(t, os.WriteFile(path+backupSuffix, []byte("clientOptions:\n  env: [\n"), 0644))
+	_, err = client.GetEnv("key")
+	assert.ErrorIs(t, err, ErrConfigCorrupt)
+}
@@ -0,0 +1,324 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// transactionFiles are the files written when a transaction is committed
+var transactionFiles = []ConfigFile{ClientConfigFile, ClientConfigNextGenFile, LegacyClientConfigFile}
+
+// ErrInTransaction is the error of acquiring the Tanzu config lock of a client
+// with the context of a transaction of the client, which holds the lock
+var ErrInTransaction = errors.New("the config is locked by the transaction in progress, use the transaction instead")
+
+// Tx is a transaction on the Tanzu config, created by Update.
+//
+// The changes made through a transaction are applied to the config held in
+// memory, and are only written when the transaction is committed. The reads
+// made through a transaction see its uncommitted changes.
+type Tx struct {
+	client  *Client
+	ctx     context.Context
+	node    *yaml.Node
+	persist bool
+}
+
+// txContextKey is the key of the client running a transaction in the context
+// of the transaction
+type txContextKey struct{}
+
+// Update runs fn in a transaction holding the Tanzu config lock, and writes the
+// changes made by fn to the config files at once when fn returns.
+// Nothing is written if fn returns an error, and if writing one of the files
+// fails, the files already written are restored. Each file is replaced
+// atomically, but the files are replaced one after the other: if the process
+// stops while they are replaced, the files may be left out of sync.
+//
+// fn must only use tx to read and update the config: the other config APIs
+// called from fn wait for the lock held by the transaction until the lock
+// timeout expires, and the lock APIs given tx.Context() return
+// ErrInTransaction. The config APIs called from other goroutines wait for the
+// transaction to end.
+func Update(fn func(tx *Tx) error) error {
+	return DefaultClient().Update(fn)
+}
+
+// Update runs fn in a transaction holding the Tanzu config lock, and writes the
+// changes made by fn to the config files at once when fn returns.
+// Nothing is written if fn returns an error, and if writing one of the files
+// fails, the files already written are restored. Each file is replaced
+// atomically, but the files are replaced one after the other: if the process
+// stops while they are replaced, the files may be left out of sync.
+//
+// fn must only use tx to read and update the config: the other config APIs
+// called from fn wait for the lock held by the transaction until the lock
+// timeout expires, and the lock APIs given tx.Context() return
+// ErrInTransaction. The config APIs called from other goroutines wait for the
+// transaction to end.
+func (client *Client) Update(fn func(tx *Tx) error) error {
+	return client.UpdateContext(context.Background(), fn)
+}
+
+// UpdateContext is like Update, and stops waiting for the Tanzu config lock
+// when ctx is done.
+func UpdateContext(ctx context.Context, fn func(tx *Tx) error) error {
+	return DefaultClient().UpdateContext(ctx, fn)
+}
+
+// UpdateContext is like Update, and stops waiting for the Tanzu config lock
+// when ctx is done.
+func (client *Client) UpdateContext(ctx context.Context, fn func(tx *Tx) error) error {
+	if err := client.AcquireTanzuConfigLockContext(ctx); err != nil {
+		return err
+	}
+	defer client.ReleaseTanzuConfigLock()
+
+	// The config is read and committed with the same setting, which cannot be
+	// read while fn runs
+	useUnifiedConfig, err := client.useUnifiedConfig()
+	if err != nil {
+		return err
+	}
+	node, err := client.getClientConfigNodeFor(useUnifiedConfig)
+	if err != nil {
+		return err
+	}
+
+	tx := &Tx{client: client, ctx: context.WithValue(ctx, txContextKey{}, client), node: node}
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if !tx.persist {
+		return nil
+	}
+	return client.commit(tx.node, useUnifiedConfig)
+}
+
+// inTransaction checks if ctx is the context of a transaction of the client
+func (client *Client) inTransaction(ctx context.Context) bool {
+	return ctx.Value(txContextKey{}) == client
+}
+
+// Context returns the context of the transaction, which is done when the
+// context given to UpdateContext is done. Acquiring the Tanzu config lock of
+// the client with it returns ErrInTransaction rather than waiting for the
+// lock held by the transaction.
+func (tx *Tx) Context() context.Context {
+	return tx.ctx
+}
+
+// commit persists the config node, restoring the previous content of the
+// config files if one of them cannot be written
+func (client *Client) commit(node *yaml.Node, useUnifiedConfig bool) error {
+	return client.replaceFiles(transactionFiles, func() error {
+		return client.persistConfigFor(node, useUnifiedConfig)
+	})
+}
+
+// replaceFiles runs write to replace the content of the config files, and
+// restores their previous content if write fails
+func (client *Client) replaceFiles(files []ConfigFile, write func() error) error {
+	previous := make(map[ConfigFile][]byte, len(files))
+	for _, file := range files {
+		data, err := client.storage.Read(file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s before updating it", client.location(file))
+		}
+		previous[file] = data
+	}
+
+	err := write()
+	if err == nil {
+		return nil
+	}
+	var rollbackErr error
+	for _, file := range files {
+		if restoreErr := client.restoreFile(file, previous[file]); restoreErr != nil && rollbackErr == nil {
+			rollbackErr = errors.Wrapf(restoreErr, "failed to restore %s", client.location(file))
+		}
+	}
+	if rollbackErr != nil {
+		return errors.Wrapf(err, "failed to update the config, and to roll back the changes: %v", rollbackErr)
+	}
+	return errors.Wrap(err, "failed to update the config, the changes were rolled back")
+}
+
+// restoreFile restores the content of a config file if it changed, removing
+// the file if it did not exist
+func (client *Client) restoreFile(file ConfigFile, data []byte) error {
+	current, err := client.storage.Read(file)
+	if err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+	if data != nil {
+		return client.storage.Write(file, data)
+	}
+	if err := client.storage.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ClientConfig returns the client config, including the changes made by the
+// transaction
+func (tx *Tx) ClientConfig() (*configtypes.ClientConfig, error) {
+	return convertNodeToClientConfig(tx.node)
+}
+
+// GetContext retrieves the context by name
+func (tx *Tx) GetContext(name string) (*configtypes.Context, error) {
+	return getContext(tx.node, name)
+}
+
+// GetCurrentContext retrieves the current context for the specified target
+func (tx *Tx) GetCurrentContext(target configtypes.Target) (*configtypes.Context, error) {
+	return getCurrentContext(tx.node, target)
+}
+
+// SetContext add or update context and currentContext
+func (tx *Tx) SetContext(c *configtypes.Context, setCurrent bool) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return tx.client.setContextAndServer(node, c, setCurrent)
+	})
+}
+
+// RemoveContext delete a context by name
+func (tx *Tx) RemoveContext(name string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return true, removeContextAndServer(node, name)
+	})
+}
+
+// SetCurrentContext sets the current context to the specified name if context is present
+func (tx *Tx) SetCurrentContext(name string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return setCurrentContextAndServer(node, name)
+	})
+}
+
+// RemoveCurrentContext removed the current context of specified context type
+func (tx *Tx) RemoveCurrentContext(target configtypes.Target) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return true, removeCurrentContextAndServer(node, target)
+	})
+}
+
+// GetEnv retrieves env value by key
+func (tx *Tx) GetEnv(key string) (string, error) {
+	return getEnv(tx.node, key)
+}
+
+// SetEnv add or update a env key and value
+func (tx *Tx) SetEnv(key, value string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return setEnv(node, key, value)
+	})
+}
+
+// DeleteEnv delete the env entry of specified key
+func (tx *Tx) DeleteEnv(key string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return true, deleteEnv(node, key)
+	})
+}
+
+// IsFeatureEnabled checks and returns whether specific plugin and key is true
+func (tx *Tx) IsFeatureEnabled(plugin, key string) (bool, error) {
+	return isFeatureEnabled(tx.node, plugin, key)
+}
+
+// SetFeature add or update plugin key value
+func (tx *Tx) SetFeature(plugin, key, value string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return setFeature(node, plugin, key, value)
+	})
+}
+
+// DeleteFeature deletes the specified plugin key
+func (tx *Tx) DeleteFeature(plugin, key string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return true, deleteFeature(node, plugin, key)
+	})
+}
+
+// SetEdition adds or updates edition value
+func (tx *Tx) SetEdition(val string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return setEdition(node, val), nil
+	})
+}
+
+// SetCLIDiscoverySource add or update a cli discoverySource
+func (tx *Tx) SetCLIDiscoverySource(discoverySource configtypes.PluginDiscovery) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return tx.client.setCLIDiscoverySource(node, discoverySource)
+	})
+}
+
+// DeleteCLIDiscoverySource delete cli discoverySource by name
+func (tx *Tx) DeleteCLIDiscoverySource(name string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return true, deleteCLIDiscoverySource(node, name)
+	})
+}
+
+// SetCLIRepository add or update a repository
+func (tx *Tx) SetCLIRepository(repository configtypes.PluginRepository) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return tx.client.setCLIRepository(node, repository)
+	})
+}
+
+// DeleteCLIRepository delete a cli repository by name
+func (tx *Tx) DeleteCLIRepository(name string) error {
+	return tx.apply(func(node *yaml.Node) (bool, error) {
+		return true, deleteCLIRepository(node, name)
+	})
+}
+
+// apply applies a change to a copy of the config of the transaction, which
+// replaces the config if the change succeeds, so that a change failing part
+// way through is not committed. It records whether the change must be
+// persisted.
+func (tx *Tx) apply(change func(node *yaml.Node) (persist bool, err error)) error {
+	node := copyNode(tx.node, map[*yaml.Node]*yaml.Node{})
+	persist, err := change(node)
+	if err != nil {
+		return err
+	}
+	tx.node = node
+	tx.persist = tx.persist || persist
+	return nil
+}
+
+// copyNode returns a deep copy of a YAML node. copies maps the nodes already
+// copied to their copy, so that aliases refer to the copy of their anchor.
+func copyNode(node *yaml.Node, copies map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if c, ok := copies[node]; ok {
+		return c
+	}
+	c := &yaml.Node{}
+	*c = *node
+	copies[node] = c
+	if node.Content != nil {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, content := range node.Content {
+			c.Content[i] = copyNode(content, copies)
+		}
+	}
+	c.Alias = copyNode(node.Alias, copies)
+	return c
+}
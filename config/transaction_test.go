@@ -0,0 +1,268 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// recordingStorage counts the writes to a storage, and fails the writes of a file
+type recordingStorage struct {
+	Storage
+	writes    map[ConfigFile]int
+	failWrite ConfigFile
+}
+
+func (s *recordingStorage) Write(file ConfigFile, data []byte) error {
+	if file == s.failWrite {
+		return errors.New("disk full")
+	}
+	s.writes[file]++
+	return s.Storage.Write(file, data)
+}
+
+func newRecordingClient(files map[ConfigFile][]byte) (*Client, *recordingStorage) {
+	storage := &recordingStorage{Storage: NewMemoryStorage(files), writes: map[ConfigFile]int{}}
+	return NewClient(WithStorage(storage)), storage
+}
+
+var txContext = &configtypes.Context{
+	Name:   "test-mc",
+	Target: configtypes.TargetK8s,
+	ClusterOpts: &configtypes.ClusterServer{
+		Endpoint: "test-endpoint",
+		Path:     "test-path",
+		Context:  "test-context",
+	},
+}
+
+func TestUpdateWritesChangesOnce(t *testing.T) {
+	client, storage := newRecordingClient(nil)
+
+	err := client.Update(func(tx *Tx) error {
+		if err := tx.SetContext(txContext, false); err != nil {
+			return err
+		}
+		if err := tx.SetCurrentContext("test-mc"); err != nil {
+			return err
+		}
+		if err := tx.SetEnv("key", "value"); err != nil {
+			return err
+		}
+		if err := tx.SetFeature("global", "feature", "true"); err != nil {
+			return err
+		}
+
+		// Reads see the changes not committed yet
+		c, err := tx.GetCurrentContext(configtypes.TargetK8s)
+		assert.NoError(t, err)
+		assert.Equal(t, "test-mc", c.Name)
+		assert.Empty(t, storage.writes)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[ConfigFile]int{ClientConfigFile: 1, ClientConfigNextGenFile: 1, LegacyClientConfigFile: 1}, storage.writes)
+
+	c, err := client.GetCurrentContext(configtypes.TargetK8s)
+	assert.NoError(t, err)
+	assert.Equal(t, txContext, c)
+	s, err := client.GetCurrentServer()
+	assert.NoError(t, err)
+	assert.Equal(t, "test-mc", s.Name)
+	val, err := client.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+	enabled, err := client.IsFeatureEnabled("global", "feature")
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+}
+
+func TestUpdateWithoutChanges(t *testing.T) {
+	client, storage := newRecordingClient(nil)
+	assert.NoError(t, client.SetEnv("key", "value"))
+	storage.writes = map[ConfigFile]int{}
+
+	err := client.Update(func(tx *Tx) error {
+		val, err := tx.GetEnv("key")
+		assert.NoError(t, err)
+		assert.Equal(t, "value", val)
+		return tx.SetEnv("key", "value")
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, storage.writes)
+}
+
+func TestUpdateDiscardsChangesOnError(t *testing.T) {
+	client, storage := newRecordingClient(nil)
+	assert.NoError(t, client.SetEnv("key", "value"))
+	storage.writes = map[ConfigFile]int{}
+
+	err := client.Update(func(tx *Tx) error {
+		assert.NoError(t, tx.SetContext(txContext, true))
+		assert.NoError(t, tx.DeleteEnv("key"))
+		return tx.SetCurrentContext("missing")
+	})
+	assert.ErrorIs(t, err, ErrContextNotFound)
+	assert.Empty(t, storage.writes)
+
+	_, err = client.GetContext("test-mc")
+	assert.ErrorIs(t, err, ErrContextNotFound)
+	val, err := client.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+}
+
+func TestUpdateRollsBackOnWriteFailure(t *testing.T) {
+	client, storage := newRecordingClient(nil)
+	assert.NoError(t, client.SetServer(&configtypes.Server{
+		Name: "test-server",
+		Type: configtypes.ManagementClusterServerType,
+		ManagementClusterOpts: &configtypes.ManagementClusterServer{
+			Endpoint: "test-endpoint",
+		},
+	}, false))
+	before := map[ConfigFile][]byte{}
+	for _, file := range transactionFiles {
+		data, err := storage.Read(file)
+		assert.NoError(t, err)
+		before[file] = data
+	}
+
+	// config.yaml is written before config-ng.yaml fails
+	storage.failWrite = ClientConfigNextGenFile
+	err := client.Update(func(tx *Tx) error {
+		return tx.SetContext(txContext, true)
+	})
+	assert.EqualError(t, err, "failed to update the config, the changes were rolled back: disk full")
+	assert.NotZero(t, storage.writes[ClientConfigFile])
+
+	for _, file := range transactionFiles {
+		data, err := storage.Read(file)
+		assert.NoError(t, err)
+		assert.Equal(t, string(before[file]), string(data), file)
+	}
+
+	storage.failWrite = ""
+	_, err = client.GetServer("test-mc")
+	assert.Error(t, err)
+	_, err = client.GetContext("test-server")
+	assert.NoError(t, err)
+}
+
+func TestUpdateRemovesContexts(t *testing.T) {
+	client, _ := newRecordingClient(nil)
+	assert.NoError(t, client.SetContext(txContext, true))
+
+	err := client.Update(func(tx *Tx) error {
+		if err := tx.RemoveCurrentContext(configtypes.TargetK8s); err != nil {
+			return err
+		}
+		return tx.RemoveContext("test-mc")
+	})
+	assert.NoError(t, err)
+
+	cfg, err := client.GetClientConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.KnownContexts)
+	assert.Empty(t, cfg.KnownServers)
+	assert.Empty(t, cfg.CurrentContext)
+}
+
+func TestUpdateReentry(t *testing.T) {
+	storage := &recordingStorage{Storage: NewMemoryStorage(nil), writes: map[ConfigFile]int{}}
+	client := NewClient(WithStorage(storage), WithLockTimeout(100*time.Millisecond))
+
+	err := client.Update(func(tx *Tx) error {
+		// The lock held by the transaction is not acquired from its context
+		err := client.AcquireTanzuConfigLockContext(tx.Context())
+		assert.ErrorIs(t, err, ErrInTransaction)
+
+		// The other config APIs wait for the lock held by the transaction
+		assert.ErrorIs(t, client.SetEnv("key", "value"), ErrLockTimeout)
+
+		// The metadata lock is not held by the transaction
+		assert.NoError(t, client.AcquireTanzuMetadataLockContext(tx.Context()))
+		assert.NoError(t, client.ReleaseTanzuMetadataLockE())
+		return err
+	})
+	assert.ErrorIs(t, err, ErrInTransaction)
+	assert.Empty(t, storage.writes)
+
+	// The client can be used again once the transaction is done
+	assert.NoError(t, client.SetEnv("key", "value"))
+}
+
+func TestUpdateConcurrentCallsWait(t *testing.T) {
+	client, _ := newRecordingClient(nil)
+
+	started := make(chan struct{})
+	done := make(chan error)
+	err := client.Update(func(tx *Tx) error {
+		go func() {
+			close(started)
+			done <- client.SetEnv("other", "value")
+		}()
+		<-started
+		select {
+		case err := <-done:
+			t.Errorf("SetEnv returned while the transaction runs: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		return tx.SetEnv("key", "value")
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+
+	envs, err := client.GetAllEnvs()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value", "other": "value"}, envs)
+}
+
+func TestUpdateKeepsConfigOfFailedChange(t *testing.T) {
+	client, _ := newRecordingClient(nil)
+
+	err := client.Update(func(tx *Tx) error {
+		assert.NoError(t, tx.SetEnv("key", "value"))
+		// Setting an unknown current context fails, without changing the config
+		assert.ErrorIs(t, tx.SetCurrentContext("missing"), ErrContextNotFound)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	val, err := client.GetEnv("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+	cfg, err := client.GetClientConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.CurrentContext)
+}
+
+func TestUpdateWithUnifiedConfig(t *testing.T) {
+	client, storage := newRecordingClient(nil)
+	assert.NoError(t, client.SetConfigMetadataSetting(SettingUseUnifiedConfig, "true"))
+	assert.NoError(t, client.SetEnv("a", "1"))
+
+	err := client.Update(func(tx *Tx) error {
+		return tx.SetEnv("b", "2")
+	})
+	assert.NoError(t, err)
+
+	data, err := storage.Read(ClientConfigNextGenFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "a: \"1\"")
+	assert.Contains(t, string(data), "b: \"2\"")
+	data, err = storage.Read(ClientConfigFile)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "b: \"2\"")
+
+	envs, err := client.GetAllEnvs()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, envs)
+}
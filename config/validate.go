@@ -0,0 +1,381 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrConfigInvalid matches the errors of config files which do not match their schema
+var ErrConfigInvalid = errors.New("config invalid")
+
+// ValidationError is a problem found in a config file by Validate
+type ValidationError struct {
+	// Path is the path of the config file, or its name in the storage
+	Path string
+
+	// Line and Column are the position of the problem in the config file
+	Line   int
+	Column int
+
+	// Field is the path of the key in the config file, e.g. contexts[0].name
+	Field string
+
+	// Message describes the problem
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", e.Path, e.Line, e.Column, e.Field, e.Message)
+}
+
+// ValidationErrors are the problems found in the config files by Validate. They
+// match ErrConfigInvalid.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is matches ErrConfigInvalid
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrConfigInvalid
+}
+
+// validatedFiles are the config files checked by Validate, with their schema
+var validatedFiles = []struct {
+	file   ConfigFile
+	schema func() *jsonSchema
+}{
+	{ClientConfigFile, clientConfigSchema},
+	{ClientConfigNextGenFile, clientConfigSchema},
+	{MetadataFile, metadataSchema},
+}
+
+// Validate checks the config files against the schema of the config types, and
+// returns ValidationErrors reporting the unknown keys, the values of the wrong
+// type, the duplicate context names and the current contexts which do not exist.
+// Config files which cannot be parsed are reported with a *ConfigCorruptError.
+func Validate() error {
+	return DefaultClient().Validate()
+}
+
+// Validate checks the config files against the schema of the config types, and
+// returns ValidationErrors reporting the unknown keys, the values of the wrong
+// type, the duplicate context names and the current contexts which do not exist.
+// Config files which cannot be parsed are reported with a *ConfigCorruptError.
+func (client *Client) Validate() error {
+	files := make([]ConfigFile, len(validatedFiles))
+	for i, validated := range validatedFiles {
+		files[i] = validated.file
+	}
+	contents, err := client.readConfigFiles(files)
+	if err != nil {
+		return err
+	}
+
+	// The current contexts may refer to the contexts of both config files
+	nodes := map[ConfigFile]*yaml.Node{}
+	contextNames := map[string]struct{}{}
+	for _, validated := range validatedFiles {
+		content, ok := contents[validated.file]
+		if !ok || strings.TrimSpace(content) == "" {
+			continue
+		}
+		node := &yaml.Node{}
+		if err = yaml.Unmarshal([]byte(content), node); err != nil {
+			return configCorrupt(client.location(validated.file), errors.Wrap(err, "failed to parse the config"))
+		}
+		nodes[validated.file] = node
+		if validated.file != MetadataFile {
+			for _, name := range contextNameNodes(node) {
+				if name != nil {
+					contextNames[name.Value] = struct{}{}
+				}
+			}
+		}
+	}
+
+	var validationErrs ValidationErrors
+	for _, validated := range validatedFiles {
+		node, ok := nodes[validated.file]
+		if !ok {
+			continue
+		}
+		v := &nodeValidator{path: client.location(validated.file)}
+		v.validate(node, validated.schema(), "")
+		if validated.file != MetadataFile {
+			v.validateContexts(node, contextNames)
+		}
+		validationErrs = append(validationErrs, v.errs...)
+	}
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+	return nil
+}
+
+// nodeValidator collects the problems of a config file
+type nodeValidator struct {
+	path string
+	errs ValidationErrors
+}
+
+// addError adds a problem, unless it was already reported at the same
+// position, which happens when an anchored mapping is merged into others
+func (v *nodeValidator) addError(node *yaml.Node, field, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	for _, err := range v.errs {
+		if err.Line == node.Line && err.Column == node.Column && err.Message == message {
+			return
+		}
+	}
+	v.errs = append(v.errs, &ValidationError{
+		Path:    v.path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Field:   field,
+		Message: message,
+	})
+}
+
+// validate checks a node against its schema
+func (v *nodeValidator) validate(node *yaml.Node, schema *jsonSchema, field string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			v.validate(content, schema, field)
+		}
+		return
+	case yaml.AliasNode:
+		v.validate(node.Alias, schema, field)
+		return
+	}
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			v.addError(node, field, "expected a mapping, got %s", describeNode(node))
+			return
+		}
+		v.validateMapping(node, schema, field)
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			v.addError(node, field, "expected a sequence, got %s", describeNode(node))
+			return
+		}
+		for i, item := range node.Content {
+			v.validate(item, schema.Items, fmt.Sprintf("%s[%d]", field, i))
+		}
+	case "":
+		// Any value
+	default:
+		if node.Kind != yaml.ScalarNode || node.Decode(reflect.New(schema.goType).Interface()) != nil {
+			v.addError(node, field, "expected %s, got %s", describeType(schema), describeNode(node))
+		}
+	}
+}
+
+// validateMapping checks the keys and values of a mapping node, and of the
+// mappings merged into it with the << key
+func (v *nodeValidator) validateMapping(node *yaml.Node, schema *jsonSchema, field string) {
+	keys := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			v.validateMerge(value, schema, field)
+			continue
+		}
+		keyField := key.Value
+		if field != "" {
+			keyField = field + "." + key.Value
+		}
+		if previous, ok := keys[key.Value]; ok {
+			v.addError(key, keyField, "duplicate key, already defined at line %d", previous.Line)
+			continue
+		}
+		keys[key.Value] = key
+
+		if property, ok := schema.Properties[key.Value]; ok {
+			v.validate(value, property, keyField)
+			continue
+		}
+		switch additional := schema.AdditionalProperties.(type) {
+		case *jsonSchema:
+			v.validate(value, additional, keyField)
+		case bool:
+			if suggestion := closestKey(key.Value, schema.Properties); suggestion != "" {
+				v.addError(key, keyField, "unknown key, did you mean %q?", suggestion)
+			} else {
+				v.addError(key, keyField, "unknown key")
+			}
+		}
+	}
+}
+
+// validateMerge checks the mapping, or the sequence of mappings, merged into
+// a mapping with the << key
+func (v *nodeValidator) validateMerge(node *yaml.Node, schema *jsonSchema, field string) {
+	merged := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		merged = node.Content
+	}
+	for _, mapping := range merged {
+		if mapping.Kind == yaml.AliasNode {
+			mapping = mapping.Alias
+		}
+		if mapping.Kind != yaml.MappingNode {
+			v.addError(mapping, field, "expected a mapping to merge, got %s", describeNode(mapping))
+			continue
+		}
+		v.validateMapping(mapping, schema, field)
+	}
+}
+
+// validateContexts checks that the context names of the config file are
+// unique, and that its current contexts are among the context names of the
+// config files
+func (v *nodeValidator) validateContexts(node *yaml.Node, contextNames map[string]struct{}) {
+	names := map[string]*yaml.Node{}
+	for i, name := range contextNameNodes(node) {
+		if name == nil {
+			continue
+		}
+		if previous, ok := names[name.Value]; ok {
+			v.addError(name, fmt.Sprintf("%s[%d].name", KeyContexts, i), "duplicate context %q, already defined at line %d", name.Value, previous.Line)
+			continue
+		}
+		names[name.Value] = name
+	}
+
+	if len(node.Content) == 0 {
+		return
+	}
+	currentContext := mappingValue(node.Content[0], KeyCurrentContext)
+	if currentContext == nil || currentContext.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(currentContext.Content); i += 2 {
+		target, name := currentContext.Content[i], currentContext.Content[i+1]
+		if name.Kind != yaml.ScalarNode || name.Value == "" {
+			continue
+		}
+		if _, ok := contextNames[name.Value]; !ok {
+			v.addError(name, KeyCurrentContext+"."+target.Value, "context %q does not exist", name.Value)
+		}
+	}
+}
+
+// contextNameNodes returns the name nodes of the contexts of a config file,
+// indexed as the contexts. The contexts without a name are nil.
+func contextNameNodes(node *yaml.Node) []*yaml.Node {
+	if len(node.Content) == 0 {
+		return nil
+	}
+	contexts := mappingValue(node.Content[0], KeyContexts)
+	if contexts == nil || contexts.Kind != yaml.SequenceNode {
+		return nil
+	}
+	names := make([]*yaml.Node, len(contexts.Content))
+	for i, context := range contexts.Content {
+		if name := mappingValue(context, "name"); name != nil && name.Kind == yaml.ScalarNode {
+			names[i] = name
+		}
+	}
+	return names
+}
+
+// mappingValue returns the value of the key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// describeNode describes a node in the validation errors
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a sequence"
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
+
+// describeType describes the type of the values of a scalar schema
+func describeType(schema *jsonSchema) string {
+	switch {
+	case schema.Format == "date-time":
+		return "a date-time"
+	case schema.Type == "integer":
+		return "an integer"
+	}
+	return "a " + schema.Type
+}
+
+// closestKey returns the property closest to an unknown key, if the key looks
+// like a typo of the property
+func closestKey(key string, properties map[string]*jsonSchema) string {
+	closest, closestDistance := "", 3
+	for property := range properties {
+		distance := editDistance(strings.ToLower(key), strings.ToLower(property))
+		if distance < closestDistance || (distance == closestDistance && closest != "" && property < closest) {
+			closest, closestDistance = property, distance
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance of two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientConfigJSONSchema(t *testing.T) {
+	data, err := ClientConfigJSONSchema()
+	assert.NoError(t, err)
+
+	var schema map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, jsonSchemaDraft, schema["$schema"])
+	assert.Equal(t, false, schema["additionalProperties"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Contains(t, properties, "apiVersion")
+	contexts := properties["contexts"].(map[string]interface{})
+	assert.Equal(t, "array", contexts["type"])
+	context := contexts["items"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Contains(t, context, "clusterOpts")
+	expiration := context["globalOpts"].(map[string]interface{})["properties"].(map[string]interface{})["auth"].(map[string]interface{})["properties"].(map[string]interface{})["expiration"]
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, expiration)
+	env := properties["clientOptions"].(map[string]interface{})["properties"].(map[string]interface{})["env"]
+	assert.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}}, env)
+
+	data, err = MetadataJSONSchema()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"patchStrategy"`)
+}
+
+func TestValidate(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(map[ConfigFile][]byte{
+		ClientConfigFile: []byte(`apiVersion: config.tanzu.vmware.com/v1alpha1
+kind: ClientConfig
+metadata:
+  creationTimestamp: null
+clientOptions:
+  features:
+    global:
+      feature: "true"
+  env:
+    key: value
+`),
+		ClientConfigNextGenFile: []byte(`contexts:
+  - name: test-mc
+    target: kubernetes
+    clusterOpt:
+      endpoint: test-endpoint
+  - name: test-tmc
+    target: mission-control
+    globalOpts:
+      auth:
+        expiration: tomorrow
+  - name: test-mc
+    target: kubernetes
+    clusterOpts:
+      isManagementCluster: maybe
+currentContext:
+  kubernetes: test-mc
+  mission-control: missing
+cli:
+  ceipOptIn: true
+  ceipOptIn: false
+`),
+		MetadataFile: []byte(`configMetadata:
+  settings: [useUnifiedConfig]
+`),
+	})))
+
+	err := client.Validate()
+	assert.ErrorIs(t, err, ErrConfigInvalid)
+	var validationErrs ValidationErrors
+	assert.True(t, errors.As(err, &validationErrs))
+
+	var messages []string
+	for _, validationErr := range validationErrs {
+		messages = append(messages, validationErr.Error())
+	}
+	assert.Equal(t, []string{
+		`config-ng:4:5: contexts[0].clusterOpt: unknown key, did you mean "clusterOpts"?`,
+		`config-ng:10:21: contexts[1].globalOpts.auth.expiration: expected a date-time, got "tomorrow"`,
+		`config-ng:14:28: contexts[2].clusterOpts.isManagementCluster: expected a boolean, got "maybe"`,
+		`config-ng:20:3: cli.ceipOptIn: duplicate key, already defined at line 19`,
+		`config-ng:11:11: contexts[2].name: duplicate context "test-mc", already defined at line 2`,
+		`config-ng:17:20: currentContext.mission-control: context "missing" does not exist`,
+		`config-metadata:2:13: configMetadata.settings: expected a mapping, got a sequence`,
+	}, messages)
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(nil)))
+	assert.NoError(t, client.Validate())
+
+	assert.NoError(t, client.SetContext(txContext, true))
+	assert.NoError(t, client.SetEnv("key", "value"))
+	assert.NoError(t, client.SetFeature("global", "feature", "true"))
+	assert.NoError(t, client.SetConfigMetadataSetting("useUnifiedConfig", "false"))
+	assert.NoError(t, client.Validate())
+}
+
+func TestValidateMergeKeys(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(map[ConfigFile][]byte{
+		ClientConfigNextGenFile: []byte(`contexts:
+  - &base
+    name: base
+    target: kubernetes
+    clusterOpt:
+      endpoint: test-endpoint
+  - <<: *base
+    name: copy
+  - <<: [*base]
+    name: list
+  - <<: base
+    name: scalar
+`),
+	})))
+
+	var validationErrs ValidationErrors
+	assert.True(t, errors.As(client.Validate(), &validationErrs))
+	var messages []string
+	for _, validationErr := range validationErrs {
+		messages = append(messages, validationErr.Error())
+	}
+	assert.Equal(t, []string{
+		`config-ng:5:5: contexts[0].clusterOpt: unknown key, did you mean "clusterOpts"?`,
+		`config-ng:11:9: contexts[3]: expected a mapping to merge, got "base"`,
+	}, messages)
+}
+
+func TestValidateCurrentContextInOtherFile(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(map[ConfigFile][]byte{
+		ClientConfigFile: []byte(`contexts:
+  - name: test-mc
+    target: kubernetes
+currentContext:
+  kubernetes: test-mc
+  mission-control: test-tmc
+`),
+		ClientConfigNextGenFile: []byte(`contexts:
+  - name: test-tmc
+    target: mission-control
+currentContext:
+  kubernetes: test-mc
+  mission-control: missing
+`),
+	})))
+
+	var validationErrs ValidationErrors
+	assert.True(t, errors.As(client.Validate(), &validationErrs))
+	assert.Len(t, validationErrs, 1)
+	assert.Equal(t, `config-ng:6:20: currentContext.mission-control: context "missing" does not exist`, validationErrs[0].Error())
+}
+
+func TestValidateCorruptConfig(t *testing.T) {
+	client := NewClient(WithStorage(NewMemoryStorage(map[ConfigFile][]byte{
+		ClientConfigFile: []byte("clientOptions: [\n"),
+	})))
+	assert.ErrorIs(t, client.Validate(), ErrConfigCorrupt)
+}